@@ -2,20 +2,29 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
+	"explore_service/internal/outbox"
 	"explore_service/internal/server"
 	"explore_service/internal/storage"
+	"explore_service/internal/sweeper"
 	explorepb "explore_service/proto"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // getEnv fetches an environment variable or returns the fallback if unset.
@@ -26,8 +35,89 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvBool fetches a boolean environment variable or returns the
+// fallback if unset.  Invalid values are fatal.
+func getEnvBool(key string, fallback bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Fatalf("environment variable %s must be a boolean: %v", key, err)
+	}
+	return b
+}
+
+// getEnvDuration fetches a duration environment variable such as
+// "5s" or returns the fallback if unset.  Invalid values are fatal.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Fatalf("environment variable %s must be a positive duration", key)
+	}
+	return d
+}
+
+// getEnvNonNegativeDuration is like getEnvDuration but also accepts
+// zero.
+func getEnvNonNegativeDuration(key string, fallback time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.Fatalf("environment variable %s must be a non-negative duration", key)
+	}
+	return d
+}
+
+// getEnvInt fetches a positive integer environment variable or returns
+// the fallback if unset.  Invalid values are fatal.
+func getEnvInt(key string, fallback int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		log.Fatalf("environment variable %s must be a positive integer", key)
+	}
+	return n
+}
+
+// getEnvNonNegativeInt is like getEnvInt but also accepts zero.
+func getEnvNonNegativeInt(key string, fallback int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		log.Fatalf("environment variable %s must be a non-negative integer", key)
+	}
+	return n
+}
+
+const usage = `usage:
+  explore-service                     serve the gRPC API
+  explore-service migrate [up]        apply all pending migrations
+  explore-service migrate down [N]    revert the last N migrations (default 1)
+  explore-service migrate status      list migrations and when they were applied
+  explore-service repair-counters     recompute user_like_counters from decisions`
+
 func main() {
 	ctx := context.Background()
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] != "migrate" && args[0] != "repair-counters" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
 	// Load environment variables from .env if present (local development convenience)
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found; continuing with existing environment variables")
@@ -39,20 +129,222 @@ func main() {
 	// Connect to Postgres using pgxpool.  Use background context for
 	// connection creation but create a derived context for migration
 	// calls where necessary.
-	pool, err := pgxpool.New(ctx, dsn)
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		log.Fatalf("invalid DATABASE_URL: %v", err)
+	}
+	// The service times every query for its metrics.
+	if len(args) == 0 {
+		cfg.ConnConfig.Tracer = storage.NewQueryTracer(prometheus.DefaultRegisterer)
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 	defer pool.Close()
-	// Initialise the store and run migrations.
-	store, err := storage.NewStore(ctx, pool)
+	switch {
+	case len(args) == 0:
+		serve(ctx, pool)
+	case args[0] == "repair-counters":
+		if err := repairCounters(ctx, pool); err != nil {
+			log.Fatalf("repair-counters: %v", err)
+		}
+	default:
+		if err := migrate(ctx, pool, args[1:]); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+	}
+}
+
+// repairCounters implements the "repair-counters" subcommand.
+func repairCounters(ctx context.Context, pool *pgxpool.Pool) error {
+	store, err := storage.NewStore(ctx, pool, storage.WithoutMigrations())
+	if err != nil {
+		return err
+	}
+	n, err := store.RepairLikeCounters(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("recomputed like counters for %d user(s)", n)
+	return nil
+}
+
+// migrate implements the "migrate" subcommand.
+func migrate(ctx context.Context, pool *pgxpool.Pool, args []string) error {
+	m, err := storage.NewMigrator(pool)
+	if err != nil {
+		return err
+	}
+	cmd := "up"
+	if len(args) > 0 {
+		cmd = args[0]
+	}
+	switch cmd {
+	case "up":
+		n, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("applied %d migration(s)", n)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+		}
+		n, err := m.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		log.Printf("reverted %d migration(s)", n)
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, st := range statuses {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = "applied " + st.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", st.Version, st.Name, applied)
+		}
+	default:
+		return fmt.Errorf("unknown command %q\n%s", cmd, usage)
+	}
+	return nil
+}
+
+// serve runs the gRPC API until SIGINT or SIGTERM.
+func serve(ctx context.Context, pool *pgxpool.Pool) {
+	// Initialise the store.  Migrations run on startup unless
+	// AUTO_MIGRATE is disabled, in which case they must have been
+	// applied with "explore-service migrate" beforehand.
+	var opts []storage.Option
+	if !getEnvBool("AUTO_MIGRATE", true) {
+		opts = append(opts, storage.WithoutMigrations())
+		m, err := storage.NewMigrator(pool)
+		if err != nil {
+			log.Fatalf("failed to load migrations: %v", err)
+		}
+		pending, err := m.Pending(ctx)
+		if err != nil {
+			log.Fatalf("failed to check migrations: %v", err)
+		}
+		if pending > 0 {
+			log.Fatalf("%d migration(s) pending; run \"explore-service migrate\" first", pending)
+		}
+	}
+	// Counts read the user_like_counters table when READ_LIKE_COUNTERS
+	// is set.  Run "explore-service repair-counters" if they drift.
+	if getEnvBool("READ_LIKE_COUNTERS", false) {
+		opts = append(opts, storage.WithLikeCounters())
+	}
+	opts = append(opts, storage.WithSuperLikeQuota(getEnvNonNegativeInt("SUPER_LIKE_DAILY_QUOTA", storage.DefaultSuperLikeQuota)))
+	// Passes expire after PASS_TTL, unless it is zero, so that the
+	// recipient resurfaces in FilterUndecided.  A pass must outlive the
+	// rewind window, or it could expire before it can be rewound.
+	rewindWindow := getEnvDuration("REWIND_WINDOW", 10*time.Minute)
+	passTTL := getEnvNonNegativeDuration("PASS_TTL", 0)
+	if passTTL > 0 {
+		if passTTL <= rewindWindow {
+			log.Fatalf("PASS_TTL (%s) must be longer than REWIND_WINDOW (%s)", passTTL, rewindWindow)
+		}
+		opts = append(opts, storage.WithPassTTL(passTTL))
+	}
+	store, err := storage.NewStore(ctx, pool, opts...)
 	if err != nil {
 		log.Fatalf("database migration failed: %v", err)
 	}
-	// Create the gRPC server and register our ExploreService.
-	grpcServer := grpc.NewServer()
-	svc := server.NewExploreServer(store, 50)
+	// Create the gRPC server and register our ExploreService.  Every
+	// unary call is counted and timed for the metrics listener, as are
+	// the pool's connections.
+	prometheus.MustRegister(storage.NewPoolCollector(pool))
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(server.MetricsInterceptor(prometheus.DefaultRegisterer)))
+	svc := server.NewExploreServer(store, getEnvInt("PAGE_SIZE", 50),
+		server.WithMaxPageSize(getEnvInt("MAX_PAGE_SIZE", 200)),
+		server.WithMaxBatchSize(getEnvInt("MAX_BATCH_SIZE", 100)),
+		server.WithMaxCandidates(getEnvInt("MAX_FILTER_CANDIDATES", 10000)),
+		server.WithIdempotencyTTL(getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour)),
+		server.WithRewindWindow(rewindWindow),
+	)
 	explorepb.RegisterExploreServiceServer(grpcServer, svc)
+	// The admin RPCs erase and export all of a user's data, so they are
+	// opt-in via ADMIN_RPCS and only meant for deployments where the
+	// port is not reachable by end users.
+	if getEnvBool("ADMIN_RPCS", false) {
+		explorepb.RegisterExploreAdminServiceServer(grpcServer, server.NewAdminServer(store, getEnvInt("USER_DATA_BATCH_SIZE", 500)))
+		log.Println("admin RPCs enabled")
+	}
+	// Report liveness and readiness through grpc.health.v1.  Readiness
+	// requires a reachable database with all migrations applied.
+	healthCtx, stopHealth := context.WithCancel(ctx)
+	defer stopHealth()
+	healthReporter := server.NewHealthReporter(store, getEnvDuration("HEALTH_CHECK_INTERVAL", 5*time.Second))
+	healthReporter.Register(grpcServer)
+	go healthReporter.Run(healthCtx)
+	// Relay the events recorded in the outbox to the sink chosen by
+	// OUTBOX_SINK.
+	relayCtx, stopRelay := context.WithCancel(ctx)
+	defer stopRelay()
+	relayDone := make(chan struct{})
+	publisher, closePublisher := newEventPublisher()
+	defer closePublisher()
+	if publisher != nil {
+		relay := outbox.NewRelay(store, publisher, getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second), 100)
+		go func() {
+			defer close(relayDone)
+			relay.Run(relayCtx)
+		}()
+	} else {
+		close(relayDone)
+	}
+	// Delete expired idempotency keys every IDEMPOTENCY_GC_INTERVAL.
+	gcCtx, stopGC := context.WithCancel(ctx)
+	defer stopGC()
+	gcDone := make(chan struct{})
+	go func() {
+		defer close(gcDone)
+		collectIdempotencyKeys(gcCtx, store, getEnvDuration("IDEMPOTENCY_GC_INTERVAL", time.Minute))
+	}()
+	// Delete expired passes every PASS_SWEEP_INTERVAL when passes
+	// expire.
+	sweepCtx, stopSweep := context.WithCancel(ctx)
+	defer stopSweep()
+	sweepDone := make(chan struct{})
+	if passTTL > 0 {
+		passSweeper := sweeper.New(store, getEnvDuration("PASS_SWEEP_INTERVAL", time.Minute), getEnvInt("PASS_SWEEP_BATCH_SIZE", 1000), prometheus.DefaultRegisterer)
+		go func() {
+			defer close(sweepDone)
+			passSweeper.Run(sweepCtx)
+		}()
+	} else {
+		close(sweepDone)
+	}
+	// Serve Prometheus metrics on their own listener, METRICS_ADDR, so
+	// that scrapes never compete with the gRPC port and the RPC
+	// metrics are not exposed to clients.  An empty address disables
+	// the listener.
+	var metricsServer *http.Server
+	if metricsAddr := getEnv("METRICS_ADDR", ":9090"); metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		metricsServer = &http.Server{Addr: metricsAddr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("metrics server exited with error: %v", err)
+			}
+		}()
+		log.Printf("metrics listening on %s", metricsAddr)
+	}
+	// Server reflection lets tools such as grpcurl discover the API
+	// without the .proto files.  It is opt-in via GRPC_REFLECTION.
+	if getEnvBool("GRPC_REFLECTION", false) {
+		reflection.Register(grpcServer)
+		log.Println("gRPC server reflection enabled")
+	}
 	// Listen on the port specified by the PORT environment variable or
 	// default to 50051.  In Docker environments this
 	// variable can be set via configuration.
@@ -74,5 +366,65 @@ func main() {
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 	<-stop
 	log.Println("Shutting down ExploreService...")
+	// Flip health to NOT_SERVING first so that probes and load
+	// balancers stop routing new requests while in-flight ones drain.
+	stopHealth()
+	healthReporter.Shutdown()
 	grpcServer.GracefulStop()
+	// Events still in the outbox are relayed after the next start.
+	stopRelay()
+	<-relayDone
+	stopGC()
+	<-gcDone
+	stopSweep()
+	<-sweepDone
+	if metricsServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_ = metricsServer.Shutdown(shutdownCtx)
+	}
+}
+
+// collectIdempotencyKeys deletes expired idempotency keys every
+// interval until ctx is cancelled.
+func collectIdempotencyKeys(ctx context.Context, store *storage.Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		n, err := store.DeleteExpiredIdempotencyKeys(ctx, 1000)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("failed to delete expired idempotency keys: %v", err)
+		case n > 0:
+			log.Printf("deleted %d expired idempotency key(s)", n)
+		}
+	}
+}
+
+// newEventPublisher returns the outbox sink selected by OUTBOX_SINK:
+// "log" (the default) logs every event, "file" appends them as JSON
+// lines to OUTBOX_FILE and "none" disables the relay, leaving events
+// in the outbox.  The returned function releases the sink.
+func newEventPublisher() (outbox.EventPublisher, func()) {
+	switch sink := getEnv("OUTBOX_SINK", "log"); sink {
+	case "log":
+		return outbox.NewLogPublisher(nil), func() {}
+	case "file":
+		p, err := outbox.NewFilePublisher(getEnv("OUTBOX_FILE", "events.jsonl"))
+		if err != nil {
+			log.Fatalf("failed to open event sink: %v", err)
+		}
+		return p, func() { _ = p.Close() }
+	case "none":
+		log.Println("outbox relay disabled")
+		return nil, func() {}
+	default:
+		log.Fatalf("environment variable OUTBOX_SINK must be log, file or none, got %q", sink)
+		return nil, nil
+	}
 }
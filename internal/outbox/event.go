@@ -0,0 +1,83 @@
+// Package outbox relays events recorded by the storage layer to
+// downstream consumers.  Events are written to an outbox in the same
+// transaction as the decision that caused them and a Relay publishes
+// them through an EventPublisher with at-least-once delivery.
+package outbox
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// EventType names the kind of an Event.
+type EventType string
+
+// Event types emitted by PutDecision, RewindDecision, the block
+// operations and DeleteUserData.
+const (
+	// LikeReceived is emitted when an actor starts liking a recipient,
+	// and again when the like becomes a super like.
+	LikeReceived EventType = "LikeReceived"
+	// MatchCreated is emitted when a like becomes mutual, including
+	// when lifting a block reveals a mutual like.
+	MatchCreated EventType = "MatchCreated"
+	// MatchRemoved is emitted when either user of a match passes or
+	// blocks the other, or the like that created it is rewound.
+	MatchRemoved EventType = "MatchRemoved"
+	// DecisionRewound is emitted when an actor undoes their latest
+	// decision on the recipient.
+	DecisionRewound EventType = "DecisionRewound"
+	// UserDataDeleted is emitted when DeleteUserData has erased
+	// everything stored about ActorID, so that downstream systems can
+	// erase their copies.  RecipientID is empty.
+	UserDataDeleted EventType = "UserDataDeleted"
+)
+
+// Event is a single outbox entry.  ActorID is the user whose decision
+// caused the event and RecipientID the other user.  SuperLike is set
+// on LikeReceived events for super likes.  ID increases with every
+// event and lets consumers discard duplicates, which at-least-once
+// delivery can produce.
+type Event struct {
+	ID          int64     `json:"id"`
+	Type        EventType `json:"type"`
+	ActorID     string    `json:"actor_user_id"`
+	RecipientID string    `json:"recipient_user_id"`
+	SuperLike   bool      `json:"super_like,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// Subject returns the NATS-compatible subject for the event, for
+// example "explore.events.match_created".
+func (e Event) Subject() string {
+	var b strings.Builder
+	b.WriteString("explore.events.")
+	for i, r := range string(e.Type) {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// EventPublisher delivers events to a downstream system.  Publish must
+// only return nil once the event has been handed off durably; the
+// relay retries events whose publication failed.
+type EventPublisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// Source is an outbox that events can be drained from.
+// *storage.Store and *storage.MemoryStore implement it.
+type Source interface {
+	// DrainOutbox passes up to limit of the oldest pending events to
+	// publish, in order, and removes those that were published.  It
+	// stops at the first publish error and returns it together with
+	// the number of events published.
+	DrainOutbox(ctx context.Context, limit int, publish func(context.Context, Event) error) (int, error)
+}
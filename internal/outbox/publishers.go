@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+)
+
+// LogPublisher writes every event to a logger.  It is useful for local
+// development and as a default when no downstream system exists.
+type LogPublisher struct {
+	logger *log.Logger
+}
+
+// NewLogPublisher constructs a LogPublisher that writes to logger, or
+// to the standard logger if logger is nil.
+func NewLogPublisher(logger *log.Logger) *LogPublisher {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogPublisher{logger: logger}
+}
+
+// Publish logs the event as JSON.
+func (p *LogPublisher) Publish(_ context.Context, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	p.logger.Printf("event %s %s", e.Subject(), data)
+	return nil
+}
+
+// fileRecord is a line written by FilePublisher.  The subject/data
+// layout mirrors a NATS message so that a file can be replayed into
+// NATS unchanged.
+type fileRecord struct {
+	Subject string `json:"subject"`
+	Data    Event  `json:"data"`
+}
+
+// FilePublisher appends events to a local file as JSON lines and syncs
+// the file after every event, so an event is durable once Publish
+// returns.
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFilePublisher opens path for appending, creating it if needed.
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event file: %w", err)
+	}
+	return &FilePublisher{file: f}, nil
+}
+
+// Publish appends the event to the file.
+func (p *FilePublisher) Publish(_ context.Context, e Event) error {
+	line, err := json.Marshal(fileRecord{Subject: e.Subject(), Data: e})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.file.Write(line); err != nil {
+		return err
+	}
+	return p.file.Sync()
+}
+
+// Close closes the underlying file.
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Relay periodically drains a Source and publishes its events.
+// Delivery is at-least-once: an event is removed from the outbox only
+// after it was published, so a crash or a failed publish leads to the
+// event being sent again.
+type Relay struct {
+	source    Source
+	publisher EventPublisher
+	interval  time.Duration
+	batchSize int
+}
+
+// NewRelay constructs a Relay that polls source every interval and
+// publishes up to batchSize events per round trip.  Sensible defaults
+// of one second and 100 events are used for non-positive values.
+func NewRelay(source Source, publisher EventPublisher, interval time.Duration, batchSize int) *Relay {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &Relay{source: source, publisher: publisher, interval: interval, batchSize: batchSize}
+}
+
+// Run relays events until ctx is done.  While the outbox holds full
+// batches they are drained back to back; otherwise the relay waits
+// for the poll interval.  Errors are logged and retried on the next
+// poll.
+func (r *Relay) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		wait := r.interval
+		n, err := r.Flush(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("outbox: relayed %d event(s) before error: %v", n, err)
+		case err == nil && n == r.batchSize:
+			wait = 0
+		}
+		timer.Reset(wait)
+	}
+}
+
+// Flush publishes one batch of events and returns how many were
+// published.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	return r.source.DrainOutbox(ctx, r.batchSize, r.publisher.Publish)
+}
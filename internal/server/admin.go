@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+
+	"explore_service/internal/storage"
+	explorepb "explore_service/proto"
+)
+
+// AdminServer implements the ExploreAdminService gRPC service.
+type AdminServer struct {
+	explorepb.UnimplementedExploreAdminServiceServer
+	store storage.DecisionStore
+	// batchSize is the number of other users whose rows DeleteUserData
+	// deletes per transaction, and the number of entries in each
+	// ExportUserData message.
+	batchSize int
+}
+
+// NewAdminServer constructs an AdminServer for the given storage
+// backend.  A default of 500 is used if batchSize is less than or
+// equal to zero.
+func NewAdminServer(store storage.DecisionStore, batchSize int) *AdminServer {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	return &AdminServer{store: store, batchSize: batchSize}
+}
+
+// DeleteUserData erases everything stored about the user.
+func (s *AdminServer) DeleteUserData(ctx context.Context, req *explorepb.DeleteUserDataRequest) (*explorepb.DeleteUserDataResponse, error) {
+	if err := validateUserID("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+	erasure, err := s.store.DeleteUserData(ctx, req.GetUserId(), s.batchSize)
+	if err != nil {
+		return nil, storageError("DeleteUserData", err)
+	}
+	return &explorepb.DeleteUserDataResponse{
+		Decisions:              uint64(erasure.Decisions),
+		DecisionEvents:         uint64(erasure.DecisionEvents),
+		Matches:                uint64(erasure.Matches),
+		Blocks:                 uint64(erasure.Blocks),
+		CompletedUnixTimestamp: erasure.Unix,
+	}, nil
+}
+
+// ExportUserData streams everything stored about the user, one batch
+// per message.
+func (s *AdminServer) ExportUserData(req *explorepb.ExportUserDataRequest, stream explorepb.ExploreAdminService_ExportUserDataServer) error {
+	if err := validateUserID("user_id", req.GetUserId()); err != nil {
+		return err
+	}
+	err := s.store.ExportUserData(stream.Context(), req.GetUserId(), s.batchSize, func(batch storage.UserDataExport) error {
+		return stream.Send(exportResponse(batch))
+	})
+	return storageError("ExportUserData", err)
+}
+
+// exportResponse converts a batch of an export into its message.
+func exportResponse(batch storage.UserDataExport) *explorepb.ExportUserDataResponse {
+	resp := &explorepb.ExportUserDataResponse{}
+	if sum := batch.Summary; sum != nil {
+		resp.Summary = &explorepb.GetLikeSummaryResponse{Likes: sum.Likes, NewLikes: sum.NewLikes, Matches: sum.Matches}
+	}
+	for _, d := range batch.Decisions {
+		resp.Decisions = append(resp.Decisions, &explorepb.ListMyDecisionsResponse_Decision{
+			RecipientUserId: d.RecipientID,
+			LikedRecipient:  d.Liked,
+			UnixTimestamp:   d.Unix,
+			DecisionType:    decisionTypeProto(d.Liked, d.SuperLike),
+		})
+	}
+	for _, e := range batch.History {
+		resp.History = append(resp.History, &explorepb.ExportUserDataResponse_HistoryEvent{
+			RecipientUserId: e.RecipientID,
+			LikedRecipient:  e.Liked,
+			DecisionType:    decisionTypeProto(e.Liked, e.SuperLike),
+			UnixTimestamp:   e.Unix,
+		})
+	}
+	for _, m := range batch.Matches {
+		resp.Matches = append(resp.Matches, &explorepb.ListMatchesResponse_Match{UserId: m.UserID, UnixTimestamp: m.Unix})
+	}
+	for _, b := range batch.Blocked {
+		resp.BlockedUsers = append(resp.BlockedUsers, &explorepb.ListBlockedResponse_BlockedUser{UserId: b.UserID, UnixTimestamp: b.Unix})
+	}
+	return resp
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"explore_service/internal/storage"
+)
+
+// clientErrors lists the storage errors caused by the request rather
+// than the storage itself, with the status reported for them.
+var clientErrors = []struct {
+	err  error
+	code codes.Code
+	msg  string
+}{
+	{storage.ErrIdempotencyKeyReused, codes.FailedPrecondition, "idempotency_key was already used for a different decision"},
+	{storage.ErrNothingToRewind, codes.FailedPrecondition, "no decision within the rewind window"},
+	{storage.ErrSuperLikeQuotaExceeded, codes.ResourceExhausted, "daily super like quota exceeded"},
+}
+
+// storageError converts an error returned by the storage layer into a
+// gRPC status.  Clients only see a generic message for the status
+// code; the underlying error is logged with the method name so that
+// database details never leave the service.
+func storageError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, e := range clientErrors {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, e.msg)
+		}
+	}
+	code := storageErrorCode(err)
+	log.Printf("%s: %s: %v", method, code, err)
+	var msg string
+	switch code {
+	case codes.InvalidArgument:
+		msg = "invalid argument"
+	case codes.Canceled:
+		msg = "request canceled"
+	case codes.DeadlineExceeded:
+		msg = "deadline exceeded"
+	case codes.Unavailable:
+		msg = "storage unavailable"
+	case codes.Aborted:
+		msg = "request aborted due to a concurrent update, retry"
+	case codes.ResourceExhausted:
+		msg = "storage overloaded"
+	default:
+		msg = "internal error"
+	}
+	return status.Error(code, msg)
+}
+
+// storageErrorCode classifies err.
+func storageErrorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, storage.ErrInvalidCursor):
+		return codes.InvalidArgument
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded), pgconn.Timeout(err):
+		return codes.DeadlineExceeded
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"), // connection exception
+			pgErr.Code == "57P01", // admin_shutdown
+			pgErr.Code == "57P02", // crash_shutdown
+			pgErr.Code == "57P03": // cannot_connect_now
+			return codes.Unavailable
+		case pgErr.Code == "40001", // serialization_failure
+			pgErr.Code == "40P01": // deadlock_detected
+			return codes.Aborted
+		case pgErr.Code == "57014": // query_canceled, e.g. statement_timeout
+			return codes.DeadlineExceeded
+		case strings.HasPrefix(pgErr.Code, "53"): // insufficient resources
+			return codes.ResourceExhausted
+		}
+		return codes.Internal
+	}
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	if errors.As(err, &connectErr) || errors.As(err, &netErr) || pgconn.SafeToRetry(err) {
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
@@ -2,7 +2,11 @@ package server
 
 import (
 	"context"
-	"strconv"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"explore_service/internal/storage"
 	explorepb "explore_service/proto"
@@ -11,97 +15,604 @@ import (
 // ExploreServer implements the ExploreService gRPC service.
 type ExploreServer struct {
 	explorepb.UnimplementedExploreServiceServer
-	store *storage.Store
+	store storage.DecisionStore
 	// pageSize controls the number of results returned per call to
 	// ListLikedYou and ListNewLikedYou.  The token returned to the
-	// client encodes the next offset.  This value can be tuned
-	// depending on expected client consumption patterns.
+	// client encodes the position of the last liker returned.  This
+	// value can be tuned depending on expected client consumption
+	// patterns.
 	pageSize int
+	// maxPageSize caps the page_size clients may request.
+	maxPageSize int
+	// maxBatchSize caps the number of decisions in a PutDecisions call.
+	maxBatchSize int
+	// maxCandidates caps the number of candidates in a FilterUndecided
+	// call.
+	maxCandidates int
+	// rewindWindow is how old a decision RewindDecision may undo.
+	rewindWindow time.Duration
+	// idempotencyTTL is how long the result of a PutDecision call with
+	// an idempotency key is remembered.
+	idempotencyTTL time.Duration
+}
+
+const (
+	// defaultMaxPageSize is the largest page_size clients may request
+	// unless WithMaxPageSize says otherwise.
+	defaultMaxPageSize = 200
+	// defaultMaxBatchSize is the largest PutDecisions batch accepted
+	// unless WithMaxBatchSize says otherwise.
+	defaultMaxBatchSize = 100
+	// defaultMaxCandidates is the largest FilterUndecided request
+	// accepted unless WithMaxCandidates says otherwise.
+	defaultMaxCandidates = 10000
+	// defaultRewindWindow is how old a decision RewindDecision may
+	// undo unless WithRewindWindow says otherwise.
+	defaultRewindWindow = 10 * time.Minute
+	// defaultIdempotencyTTL is how long idempotency keys are remembered
+	// unless WithIdempotencyTTL says otherwise.
+	defaultIdempotencyTTL = 24 * time.Hour
+)
+
+// Option configures an ExploreServer.
+type Option func(*ExploreServer)
+
+// WithMaxPageSize sets the largest page_size clients may request.
+// Larger requests are clamped to it.  It is raised to the default page
+// size if smaller.
+func WithMaxPageSize(n int) Option {
+	return func(s *ExploreServer) { s.maxPageSize = n }
+}
+
+// WithMaxBatchSize sets the largest number of decisions a PutDecisions
+// call may carry.  Larger batches are rejected.  Values below one are
+// ignored.
+func WithMaxBatchSize(n int) Option {
+	return func(s *ExploreServer) {
+		if n > 0 {
+			s.maxBatchSize = n
+		}
+	}
+}
+
+// WithMaxCandidates sets the largest number of candidates a
+// FilterUndecided call may carry.  Larger requests are rejected.
+// Values below one are ignored.
+func WithMaxCandidates(n int) Option {
+	return func(s *ExploreServer) {
+		if n > 0 {
+			s.maxCandidates = n
+		}
+	}
+}
+
+// WithRewindWindow sets how old a decision RewindDecision may undo.
+// Values below one second are ignored.
+func WithRewindWindow(d time.Duration) Option {
+	return func(s *ExploreServer) {
+		if d >= time.Second {
+			s.rewindWindow = d
+		}
+	}
+}
+
+// WithIdempotencyTTL sets how long the result of a PutDecision call
+// with an idempotency key is remembered.  Retries after that are
+// treated as new decisions.  Values below one second are ignored.
+func WithIdempotencyTTL(d time.Duration) Option {
+	return func(s *ExploreServer) {
+		if d >= time.Second {
+			s.idempotencyTTL = d
+		}
+	}
 }
 
 // NewExploreServer constructs a new ExploreServer with the given
-// storage backend.  pageSize controls the default number of
+// storage backend, either a PostgreSQL backed *storage.Store or a
+// *storage.MemoryStore.  pageSize controls the default number of
 // likers returned per page.  A sensible default of 50 is used if
 // pageSize is less than or equal to zero.
-func NewExploreServer(store *storage.Store, pageSize int) *ExploreServer {
+func NewExploreServer(store storage.DecisionStore, pageSize int, opts ...Option) *ExploreServer {
 	if pageSize <= 0 {
 		pageSize = 50
 	}
-	return &ExploreServer{store: store, pageSize: pageSize}
+	s := &ExploreServer{
+		store:          store,
+		pageSize:       pageSize,
+		maxPageSize:    defaultMaxPageSize,
+		maxBatchSize:   defaultMaxBatchSize,
+		maxCandidates:  defaultMaxCandidates,
+		rewindWindow:   defaultRewindWindow,
+		idempotencyTTL: defaultIdempotencyTTL,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.maxPageSize = max(s.maxPageSize, s.pageSize)
+	return s
 }
 
 // PutDecision records a decision and returns whether the like is mutual.
+// With an idempotency key a retried call returns the original result.
 func (s *ExploreServer) PutDecision(ctx context.Context, req *explorepb.PutDecisionRequest) (*explorepb.PutDecisionResponse, error) {
-	mutual, err := s.store.PutDecision(ctx, req.GetActorUserId(), req.GetRecipientUserId(), req.GetLikedRecipient())
+	if err := validateDecision(req.GetActorUserId(), req.GetRecipientUserId()); err != nil {
+		return nil, err
+	}
+	decision, err := parseDecisionType(req.GetDecisionType(), req.GetLikedRecipient())
 	if err != nil {
 		return nil, err
 	}
+	var mutual bool
+	if req.IdempotencyKey != nil {
+		if err := validateIdempotencyKey(req.GetIdempotencyKey()); err != nil {
+			return nil, err
+		}
+		mutual, err = s.store.PutDecisionOnce(ctx, req.GetIdempotencyKey(), s.idempotencyTTL, req.GetActorUserId(), req.GetRecipientUserId(), decision)
+	} else {
+		mutual, err = s.store.PutDecision(ctx, req.GetActorUserId(), req.GetRecipientUserId(), decision)
+	}
+	if err != nil {
+		return nil, storageError("PutDecision", err)
+	}
 	return &explorepb.PutDecisionResponse{MutualLikes: mutual}, nil
 }
 
-// ListLikedYou returns all actors who have liked the recipient.  The
-// pagination token, if present, is interpreted as the numeric offset
-// into the result set.  A new token is returned if additional
-// results are available.
-func (s *ExploreServer) ListLikedYou(ctx context.Context, req *explorepb.ListLikedYouRequest) (*explorepb.ListLikedYouResponse, error) {
-	offset := 0
-	if tok := req.GetPaginationToken(); tok != "" {
-		// parse the offset encoded as a string.  Ignore errors and
-		// fall back to zero.
-		if o, err := strconv.Atoi(tok); err == nil && o >= 0 {
-			offset = o
+// PutDecisions records a batch of decisions by one actor.  Decisions
+// that fail validation are reported in their results and not passed to
+// the store; the rest are stored in a single transaction.
+func (s *ExploreServer) PutDecisions(ctx context.Context, req *explorepb.PutDecisionsRequest) (*explorepb.PutDecisionsResponse, error) {
+	actorID := req.GetActorUserId()
+	if err := validateUserID("actor_user_id", actorID); err != nil {
+		return nil, err
+	}
+	switch n := len(req.GetDecisions()); {
+	case n == 0:
+		return nil, status.Error(codes.InvalidArgument, "decisions is required")
+	case n > s.maxBatchSize:
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d decisions may be put at once", s.maxBatchSize)
+	}
+	results := make([]*explorepb.PutDecisionsResponse_Result, len(req.GetDecisions()))
+	var valid []storage.Decision
+	// index maps each valid decision to its position in the request.
+	var index []int
+	for i, d := range req.GetDecisions() {
+		if err := validateDecision(actorID, d.GetRecipientUserId()); err != nil {
+			results[i] = decisionErrorResult(err)
+			continue
+		}
+		decision, err := parseDecisionType(d.GetDecisionType(), d.GetLikedRecipient())
+		if err != nil {
+			results[i] = decisionErrorResult(err)
+			continue
 		}
+		valid = append(valid, storage.Decision{RecipientID: d.GetRecipientUserId(), Type: decision})
+		index = append(index, i)
 	}
-	likers, next, err := s.store.ListLikedYou(ctx, req.GetRecipientUserId(), offset, s.pageSize)
+	stored, err := s.store.PutDecisions(ctx, actorID, valid)
 	if err != nil {
-		return nil, err
+		return nil, storageError("PutDecisions", err)
 	}
-	resp := &explorepb.ListLikedYouResponse{Likers: make([]*explorepb.ListLikedYouResponse_Liker, len(likers))}
-	for i, l := range likers {
-		resp.Likers[i] = &explorepb.ListLikedYouResponse_Liker{
-			ActorId:       l.ActorID,
-			UnixTimestamp: l.Unix,
+	for j, r := range stored {
+		if r.Err != nil {
+			results[index[j]] = decisionErrorResult(storageError("PutDecisions", r.Err))
+			continue
 		}
+		results[index[j]] = &explorepb.PutDecisionsResponse_Result{MutualLikes: r.Mutual}
 	}
-	if next != nil {
-		resp.NextPaginationToken = next
+	return &explorepb.PutDecisionsResponse{Results: results}, nil
+}
+
+// parseDecisionType returns the decision selected by a request.  An
+// unspecified decision_type falls back to liked_recipient, which older
+// clients send on its own.
+func parseDecisionType(t explorepb.DecisionType, liked bool) (storage.DecisionType, error) {
+	switch t {
+	case explorepb.DecisionType_DECISION_TYPE_UNSPECIFIED:
+		if liked {
+			return storage.Like, nil
+		}
+		return storage.Pass, nil
+	case explorepb.DecisionType_DECISION_TYPE_PASS:
+		if liked {
+			return 0, status.Error(codes.InvalidArgument, "liked_recipient contradicts decision_type")
+		}
+		return storage.Pass, nil
+	case explorepb.DecisionType_DECISION_TYPE_LIKE:
+		return storage.Like, nil
+	case explorepb.DecisionType_DECISION_TYPE_SUPER_LIKE:
+		return storage.SuperLike, nil
+	}
+	return 0, status.Error(codes.InvalidArgument, "invalid decision_type")
+}
+
+// decisionTypeProto returns the wire decision_type of a stored
+// decision.
+func decisionTypeProto(liked, superLike bool) explorepb.DecisionType {
+	switch {
+	case superLike:
+		return explorepb.DecisionType_DECISION_TYPE_SUPER_LIKE
+	case liked:
+		return explorepb.DecisionType_DECISION_TYPE_LIKE
+	}
+	return explorepb.DecisionType_DECISION_TYPE_PASS
+}
+
+// decisionErrorResult reports a gRPC status error as the result of one
+// decision in a PutDecisions batch.
+func decisionErrorResult(err error) *explorepb.PutDecisionsResponse_Result {
+	st := status.Convert(err)
+	return &explorepb.PutDecisionsResponse_Result{Code: int32(st.Code()), ErrorMessage: st.Message()}
+}
+
+// RewindDecision undoes the actor's latest decision within the rewind
+// window.
+func (s *ExploreServer) RewindDecision(ctx context.Context, req *explorepb.RewindDecisionRequest) (*explorepb.RewindDecisionResponse, error) {
+	if err := validateUserID("actor_user_id", req.GetActorUserId()); err != nil {
+		return nil, err
+	}
+	r, err := s.store.RewindDecision(ctx, req.GetActorUserId(), s.rewindWindow)
+	if err != nil {
+		return nil, storageError("RewindDecision", err)
+	}
+	resp := &explorepb.RewindDecisionResponse{RecipientUserId: r.RecipientID, LikedRecipient: r.Liked}
+	if r.Restored != nil {
+		decisionType := decisionTypeProto(r.Restored.Liked, r.Restored.SuperLike)
+		resp.RestoredLikedRecipient = &r.Restored.Liked
+		resp.RestoredDecisionType = &decisionType
 	}
 	return resp, nil
 }
 
+// ListLikedYou returns all actors who have liked the recipient.  The
+// pagination token, if present, is an opaque cursor returned by a
+// previous call.  A new token is returned if additional results are
+// available.
+func (s *ExploreServer) ListLikedYou(ctx context.Context, req *explorepb.ListLikedYouRequest) (*explorepb.ListLikedYouResponse, error) {
+	if err := validateUserID("recipient_user_id", req.GetRecipientUserId()); err != nil {
+		return nil, err
+	}
+	after, limit, order, err := s.listLikersOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	window, err := parseTimeRange(req.SinceUnixTimestamp, req.UntilUnixTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	likers, next, err := s.store.ListLikedYou(ctx, req.GetRecipientUserId(), window, after, limit, order)
+	if err != nil {
+		return nil, storageError("ListLikedYou", err)
+	}
+	return likersResponse(likers, next), nil
+}
+
 // ListNewLikedYou returns actors who like the recipient but have not
 // been liked back.  Pagination works in the same way as
 // ListLikedYou.
 func (s *ExploreServer) ListNewLikedYou(ctx context.Context, req *explorepb.ListLikedYouRequest) (*explorepb.ListLikedYouResponse, error) {
-	offset := 0
-	if tok := req.GetPaginationToken(); tok != "" {
-		if o, err := strconv.Atoi(tok); err == nil && o >= 0 {
-			offset = o
-		}
+	if err := validateUserID("recipient_user_id", req.GetRecipientUserId()); err != nil {
+		return nil, err
+	}
+	after, limit, order, err := s.listLikersOptions(req)
+	if err != nil {
+		return nil, err
 	}
-	likers, next, err := s.store.ListNewLikedYou(ctx, req.GetRecipientUserId(), offset, s.pageSize)
+	window, err := parseTimeRange(req.SinceUnixTimestamp, req.UntilUnixTimestamp)
 	if err != nil {
 		return nil, err
 	}
+	likers, next, err := s.store.ListNewLikedYou(ctx, req.GetRecipientUserId(), window, after, limit, order)
+	if err != nil {
+		return nil, storageError("ListNewLikedYou", err)
+	}
+	return likersResponse(likers, next), nil
+}
+
+// parsePaginationToken decodes a client supplied pagination token.
+// An empty token selects the first page; a malformed one is rejected
+// with InvalidArgument rather than silently restarting the listing.
+func parsePaginationToken(token string) (*storage.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	c, err := storage.ParseCursor(token)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid pagination_token")
+	}
+	return &c, nil
+}
+
+// listLikersOptions resolves the position, page size and order of a
+// liker listing.  Without an explicit order a pagination token keeps
+// the order it was created with; an explicit order must match it.
+func (s *ExploreServer) listLikersOptions(req *explorepb.ListLikedYouRequest) (*storage.Cursor, int, storage.Order, error) {
+	after, err := parsePaginationToken(req.GetPaginationToken())
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	var order storage.Order
+	switch req.GetOrder() {
+	case explorepb.Order_ORDER_UNSPECIFIED:
+		if after != nil {
+			order = after.Order
+		}
+	case explorepb.Order_ORDER_NEWEST_FIRST:
+		order = storage.NewestFirst
+	case explorepb.Order_ORDER_OLDEST_FIRST:
+		order = storage.OldestFirst
+	default:
+		return nil, 0, 0, status.Error(codes.InvalidArgument, "invalid order")
+	}
+	if after != nil && after.Order != order {
+		return nil, 0, 0, status.Error(codes.InvalidArgument, "order does not match pagination_token")
+	}
+	return after, s.limit(req.PageSize), order, nil
+}
+
+// limit returns the number of results to list for a client requested
+// page size: the default page size if unset and at most the maximum.
+func (s *ExploreServer) limit(pageSize *uint32) int {
+	if pageSize == nil || *pageSize == 0 {
+		return s.pageSize
+	}
+	return int(min(*pageSize, uint32(s.maxPageSize)))
+}
+
+// likersResponse converts a page of likers into the wire response,
+// encoding next as the continuation token.
+func likersResponse(likers []storage.Liker, next *storage.Cursor) *explorepb.ListLikedYouResponse {
 	resp := &explorepb.ListLikedYouResponse{Likers: make([]*explorepb.ListLikedYouResponse_Liker, len(likers))}
 	for i, l := range likers {
 		resp.Likers[i] = &explorepb.ListLikedYouResponse_Liker{
 			ActorId:       l.ActorID,
 			UnixTimestamp: l.Unix,
+			SuperLike:     l.SuperLike,
 		}
 	}
 	if next != nil {
-		resp.NextPaginationToken = next
+		token := next.Encode()
+		resp.NextPaginationToken = &token
 	}
-	return resp, nil
+	return resp
 }
 
 // CountLikedYou returns the total number of actors who liked the
 // recipient.  No pagination is required for counts.
 func (s *ExploreServer) CountLikedYou(ctx context.Context, req *explorepb.CountLikedYouRequest) (*explorepb.CountLikedYouResponse, error) {
-	count, err := s.store.CountLikedYou(ctx, req.GetRecipientUserId())
+	if err := validateUserID("recipient_user_id", req.GetRecipientUserId()); err != nil {
+		return nil, err
+	}
+	window, err := parseTimeRange(req.SinceUnixTimestamp, req.UntilUnixTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.store.CountLikedYou(ctx, req.GetRecipientUserId(), window)
 	if err != nil {
+		return nil, storageError("CountLikedYou", err)
+	}
+	return &explorepb.CountLikedYouResponse{Count: count}, nil
+}
+
+// CountNewLikedYou returns the number of actors who liked the
+// recipient and have not been liked back.
+func (s *ExploreServer) CountNewLikedYou(ctx context.Context, req *explorepb.CountLikedYouRequest) (*explorepb.CountLikedYouResponse, error) {
+	if err := validateUserID("recipient_user_id", req.GetRecipientUserId()); err != nil {
 		return nil, err
 	}
+	window, err := parseTimeRange(req.SinceUnixTimestamp, req.UntilUnixTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.store.CountNewLikedYou(ctx, req.GetRecipientUserId(), window)
+	if err != nil {
+		return nil, storageError("CountNewLikedYou", err)
+	}
 	return &explorepb.CountLikedYouResponse{Count: count}, nil
 }
+
+// GetLikeSummary returns the user's like, new like and match counts.
+func (s *ExploreServer) GetLikeSummary(ctx context.Context, req *explorepb.GetLikeSummaryRequest) (*explorepb.GetLikeSummaryResponse, error) {
+	if err := validateUserID("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+	sum, err := s.store.GetLikeSummary(ctx, req.GetUserId())
+	if err != nil {
+		return nil, storageError("GetLikeSummary", err)
+	}
+	return &explorepb.GetLikeSummaryResponse{Likes: sum.Likes, NewLikes: sum.NewLikes, Matches: sum.Matches}, nil
+}
+
+// ListMatches returns the users the given user has a mutual like
+// with, most recent match first.  Pagination works in the same way as
+// ListLikedYou.
+func (s *ExploreServer) ListMatches(ctx context.Context, req *explorepb.ListMatchesRequest) (*explorepb.ListMatchesResponse, error) {
+	if err := validateUserID("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+	after, err := parsePaginationToken(req.GetPaginationToken())
+	if err != nil {
+		return nil, err
+	}
+	matches, next, err := s.store.ListMatches(ctx, req.GetUserId(), after, s.pageSize)
+	if err != nil {
+		return nil, storageError("ListMatches", err)
+	}
+	resp := &explorepb.ListMatchesResponse{Matches: make([]*explorepb.ListMatchesResponse_Match, len(matches))}
+	for i, m := range matches {
+		resp.Matches[i] = &explorepb.ListMatchesResponse_Match{
+			UserId:        m.UserID,
+			UnixTimestamp: m.Unix,
+		}
+	}
+	if next != nil {
+		token := next.Encode()
+		resp.NextPaginationToken = &token
+	}
+	return resp, nil
+}
+
+// GetDecision returns the actor's current decision on the recipient.
+func (s *ExploreServer) GetDecision(ctx context.Context, req *explorepb.GetDecisionRequest) (*explorepb.GetDecisionResponse, error) {
+	if err := validateDecision(req.GetActorUserId(), req.GetRecipientUserId()); err != nil {
+		return nil, err
+	}
+	d, ok, err := s.store.GetDecision(ctx, req.GetActorUserId(), req.GetRecipientUserId())
+	if err != nil {
+		return nil, storageError("GetDecision", err)
+	}
+	if !ok {
+		return &explorepb.GetDecisionResponse{}, nil
+	}
+	return &explorepb.GetDecisionResponse{
+		Decided:        true,
+		LikedRecipient: d.Liked,
+		UnixTimestamp:  d.Unix,
+		DecisionType:   decisionTypeProto(d.Liked, d.SuperLike),
+	}, nil
+}
+
+// ListMyDecisions returns the actor's decisions, most recently updated
+// first.  Pagination works in the same way as ListMatches.
+func (s *ExploreServer) ListMyDecisions(ctx context.Context, req *explorepb.ListMyDecisionsRequest) (*explorepb.ListMyDecisionsResponse, error) {
+	if err := validateUserID("actor_user_id", req.GetActorUserId()); err != nil {
+		return nil, err
+	}
+	var filter storage.DecisionFilter
+	switch req.GetFilter() {
+	case explorepb.DecisionFilter_DECISION_FILTER_ALL:
+		filter = storage.AllDecisions
+	case explorepb.DecisionFilter_DECISION_FILTER_LIKED:
+		filter = storage.LikedDecisions
+	case explorepb.DecisionFilter_DECISION_FILTER_PASSED:
+		filter = storage.PassedDecisions
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid filter")
+	}
+	after, err := parsePaginationToken(req.GetPaginationToken())
+	if err != nil {
+		return nil, err
+	}
+	decisions, next, err := s.store.ListMyDecisions(ctx, req.GetActorUserId(), filter, after, s.limit(req.PageSize))
+	if err != nil {
+		return nil, storageError("ListMyDecisions", err)
+	}
+	resp := &explorepb.ListMyDecisionsResponse{Decisions: make([]*explorepb.ListMyDecisionsResponse_Decision, len(decisions))}
+	for i, d := range decisions {
+		resp.Decisions[i] = &explorepb.ListMyDecisionsResponse_Decision{
+			RecipientUserId: d.RecipientID,
+			LikedRecipient:  d.Liked,
+			UnixTimestamp:   d.Unix,
+			DecisionType:    decisionTypeProto(d.Liked, d.SuperLike),
+		}
+	}
+	if next != nil {
+		token := next.Encode()
+		resp.NextPaginationToken = &token
+	}
+	return resp, nil
+}
+
+// FilterUndecided returns the candidates the actor has not decided on
+// and has no block with.
+func (s *ExploreServer) FilterUndecided(ctx context.Context, req *explorepb.FilterUndecidedRequest) (*explorepb.FilterUndecidedResponse, error) {
+	if err := validateUserID("actor_user_id", req.GetActorUserId()); err != nil {
+		return nil, err
+	}
+	if n := len(req.GetCandidateIds()); n > s.maxCandidates {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d candidate_ids may be filtered at once", s.maxCandidates)
+	}
+	for i, id := range req.GetCandidateIds() {
+		if err := validateUserID(fmt.Sprintf("candidate_ids[%d]", i), id); err != nil {
+			return nil, err
+		}
+	}
+	ids, err := s.store.FilterUndecided(ctx, req.GetActorUserId(), req.GetCandidateIds())
+	if err != nil {
+		return nil, storageError("FilterUndecided", err)
+	}
+	return &explorepb.FilterUndecidedResponse{UndecidedIds: ids}, nil
+}
+
+// BlockUser blocks a user, hiding the two users from each other's
+// likes and removing their match.
+func (s *ExploreServer) BlockUser(ctx context.Context, req *explorepb.BlockUserRequest) (*explorepb.BlockUserResponse, error) {
+	if err := validateBlock(req.GetUserId(), req.GetBlockedUserId()); err != nil {
+		return nil, err
+	}
+	if err := s.store.BlockUser(ctx, req.GetUserId(), req.GetBlockedUserId()); err != nil {
+		return nil, storageError("BlockUser", err)
+	}
+	return &explorepb.BlockUserResponse{}, nil
+}
+
+// UnblockUser lifts a block.
+func (s *ExploreServer) UnblockUser(ctx context.Context, req *explorepb.UnblockUserRequest) (*explorepb.UnblockUserResponse, error) {
+	if err := validateBlock(req.GetUserId(), req.GetBlockedUserId()); err != nil {
+		return nil, err
+	}
+	if err := s.store.UnblockUser(ctx, req.GetUserId(), req.GetBlockedUserId()); err != nil {
+		return nil, storageError("UnblockUser", err)
+	}
+	return &explorepb.UnblockUserResponse{}, nil
+}
+
+// SetUserVisibility hides or shows a user.
+func (s *ExploreServer) SetUserVisibility(ctx context.Context, req *explorepb.SetUserVisibilityRequest) (*explorepb.SetUserVisibilityResponse, error) {
+	if err := validateUserID("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+	if err := s.store.SetUserVisibility(ctx, req.GetUserId(), req.GetHidden()); err != nil {
+		return nil, storageError("SetUserVisibility", err)
+	}
+	return &explorepb.SetUserVisibilityResponse{}, nil
+}
+
+// ListBlocked returns the users the given user has blocked, most
+// recent block first.  Pagination works in the same way as
+// ListMatches.
+func (s *ExploreServer) ListBlocked(ctx context.Context, req *explorepb.ListBlockedRequest) (*explorepb.ListBlockedResponse, error) {
+	if err := validateUserID("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+	after, err := parsePaginationToken(req.GetPaginationToken())
+	if err != nil {
+		return nil, err
+	}
+	blocked, next, err := s.store.ListBlocked(ctx, req.GetUserId(), after, s.limit(req.PageSize))
+	if err != nil {
+		return nil, storageError("ListBlocked", err)
+	}
+	resp := &explorepb.ListBlockedResponse{BlockedUsers: make([]*explorepb.ListBlockedResponse_BlockedUser, len(blocked))}
+	for i, b := range blocked {
+		resp.BlockedUsers[i] = &explorepb.ListBlockedResponse_BlockedUser{
+			UserId:        b.UserID,
+			UnixTimestamp: b.Unix,
+		}
+	}
+	if next != nil {
+		token := next.Encode()
+		resp.NextPaginationToken = &token
+	}
+	return resp, nil
+}
+
+// GetDecisionHistory returns the decisions the actor has recorded for
+// the recipient, oldest first.
+func (s *ExploreServer) GetDecisionHistory(ctx context.Context, req *explorepb.GetDecisionHistoryRequest) (*explorepb.GetDecisionHistoryResponse, error) {
+	if err := validateDecision(req.GetActorUserId(), req.GetRecipientUserId()); err != nil {
+		return nil, err
+	}
+	events, err := s.store.GetDecisionHistory(ctx, req.GetActorUserId(), req.GetRecipientUserId())
+	if err != nil {
+		return nil, storageError("GetDecisionHistory", err)
+	}
+	resp := &explorepb.GetDecisionHistoryResponse{Events: make([]*explorepb.GetDecisionHistoryResponse_DecisionEvent, len(events))}
+	for i, e := range events {
+		resp.Events[i] = &explorepb.GetDecisionHistoryResponse_DecisionEvent{
+			LikedRecipient: e.Liked,
+			UnixTimestamp:  e.Unix,
+			DecisionType:   decisionTypeProto(e.Liked, e.SuperLike),
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	explorepb "explore_service/proto"
+)
+
+// Service names reported by the grpc.health.v1 service in addition to
+// the overall ("") status and the ExploreService itself.
+const (
+	// LivenessService is SERVING while the process is up and accepting
+	// RPCs, regardless of the database.
+	LivenessService = "liveness"
+	// ReadinessService is SERVING only while the storage backend is
+	// ready to serve requests.
+	ReadinessService = "readiness"
+)
+
+// ReadinessProbe reports whether a dependency can serve requests.
+// *storage.Store and *storage.MemoryStore implement it.
+type ReadinessProbe interface {
+	Ready(ctx context.Context) error
+}
+
+// HealthReporter publishes the service health through the standard
+// grpc.health.v1 service.  Readiness follows the result of a periodic
+// probe, liveness only reflects whether the server is shutting down.
+type HealthReporter struct {
+	health   *health.Server
+	probe    ReadinessProbe
+	interval time.Duration
+
+	mu    sync.Mutex
+	ready bool
+}
+
+// NewHealthReporter constructs a HealthReporter that checks probe
+// every interval once Run is called.  Until the first check completes
+// the service reports NOT_SERVING for readiness.
+func NewHealthReporter(probe ReadinessProbe, interval time.Duration) *HealthReporter {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	h := &HealthReporter{health: health.NewServer(), probe: probe, interval: interval}
+	h.health.SetServingStatus(LivenessService, healthpb.HealthCheckResponse_SERVING)
+	h.setReady(false)
+	return h
+}
+
+// Register registers the health service on s.
+func (h *HealthReporter) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, h.health)
+}
+
+// Run checks readiness immediately and then every interval until ctx
+// is done.
+func (h *HealthReporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		h.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check probes readiness once and updates the reported status.  Each
+// probe is bounded by the check interval.
+func (h *HealthReporter) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, h.interval)
+	defer cancel()
+	err := h.probe.Ready(ctx)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err != nil && h.ready {
+		log.Printf("health: not ready: %v", err)
+	} else if err == nil && !h.ready {
+		log.Println("health: ready")
+	}
+	h.setReadyLocked(err == nil)
+}
+
+// Shutdown reports NOT_SERVING for every service, including liveness,
+// and ignores further probe results.  Call it before
+// grpc.Server.GracefulStop so that clients stop sending traffic while
+// in-flight requests drain.
+func (h *HealthReporter) Shutdown() {
+	h.health.Shutdown()
+}
+
+func (h *HealthReporter) setReady(ready bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.setReadyLocked(ready)
+}
+
+func (h *HealthReporter) setReadyLocked(ready bool) {
+	h.ready = ready
+	st := healthpb.HealthCheckResponse_NOT_SERVING
+	if ready {
+		st = healthpb.HealthCheckResponse_SERVING
+	}
+	for _, service := range []string{"", ReadinessService, explorepb.ExploreService_ServiceDesc.ServiceName} {
+		h.health.SetServingStatus(service, st)
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsInterceptor returns a unary server interceptor that counts
+// requests by method and status code and records their latency by
+// method in Prometheus.  The metrics are registered with reg unless it
+// is nil.  Methods are labelled with their full name, such as
+// "/explore.ExploreService/PutDecision".
+func MetricsInterceptor(reg prometheus.Registerer) grpc.UnaryServerInterceptor {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "explore_grpc_requests_total",
+		Help: "Unary gRPC requests handled, by method and status code.",
+	}, []string{"method", "code"})
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "explore_grpc_request_duration_seconds",
+		Help:    "Time taken to handle unary gRPC requests, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	if reg != nil {
+		reg.MustRegister(requests, duration)
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		duration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		requests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+		return resp, err
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"explore_service/internal/storage"
+)
+
+// maxUserIDLength bounds the size of user identifiers accepted by the
+// service, in bytes.
+const maxUserIDLength = 128
+
+// maxIdempotencyKeyLength bounds the size of idempotency keys, in
+// bytes.
+const maxIdempotencyKeyLength = 128
+
+// maxUnixTimestamp is the largest unix timestamp accepted in requests,
+// the last second of the year 9999.
+const maxUnixTimestamp = 253402300799
+
+// validateUserID checks that id is a usable user identifier.  field is
+// the request field name reported back to the client.
+func validateUserID(field, id string) error {
+	switch {
+	case id == "":
+		return status.Errorf(codes.InvalidArgument, "%s is required", field)
+	case len(id) > maxUserIDLength:
+		return status.Errorf(codes.InvalidArgument, "%s must be at most %d bytes", field, maxUserIDLength)
+	case !utf8.ValidString(id):
+		return status.Errorf(codes.InvalidArgument, "%s must be valid UTF-8", field)
+	}
+	return nil
+}
+
+// validateDecision checks the identifiers of a decision.  Users cannot
+// decide on themselves.
+func validateDecision(actorID, recipientID string) error {
+	if err := validateUserID("actor_user_id", actorID); err != nil {
+		return err
+	}
+	if err := validateUserID("recipient_user_id", recipientID); err != nil {
+		return err
+	}
+	if actorID == recipientID {
+		return status.Error(codes.InvalidArgument, "actor_user_id and recipient_user_id must differ")
+	}
+	return nil
+}
+
+// validateBlock checks the identifiers of a block.  Users cannot
+// block themselves.
+func validateBlock(userID, blockedID string) error {
+	if err := validateUserID("user_id", userID); err != nil {
+		return err
+	}
+	if err := validateUserID("blocked_user_id", blockedID); err != nil {
+		return err
+	}
+	if userID == blockedID {
+		return status.Error(codes.InvalidArgument, "user_id and blocked_user_id must differ")
+	}
+	return nil
+}
+
+// validateIdempotencyKey checks a client supplied idempotency key.
+func validateIdempotencyKey(key string) error {
+	switch {
+	case key == "":
+		return status.Error(codes.InvalidArgument, "idempotency_key must not be empty")
+	case len(key) > maxIdempotencyKeyLength:
+		return status.Errorf(codes.InvalidArgument, "idempotency_key must be at most %d bytes", maxIdempotencyKeyLength)
+	case !utf8.ValidString(key):
+		return status.Error(codes.InvalidArgument, "idempotency_key must be valid UTF-8")
+	}
+	return nil
+}
+
+// parseTimeRange converts optional since/until bounds in unix seconds
+// into a storage.TimeRange.  Reported timestamps are rounded to the
+// nearest second, so the bounds are shifted back by half a second:
+// a row is then selected exactly when its reported timestamp t
+// satisfies since <= t < until.
+func parseTimeRange(since, until *uint64) (storage.TimeRange, error) {
+	var r storage.TimeRange
+	bound := func(field string, v *uint64) (time.Time, error) {
+		if v == nil {
+			return time.Time{}, nil
+		}
+		if *v > maxUnixTimestamp {
+			return time.Time{}, status.Errorf(codes.InvalidArgument, "%s is out of range", field)
+		}
+		return time.Unix(int64(*v), 0).Add(-500 * time.Millisecond), nil
+	}
+	var err error
+	if r.Since, err = bound("since_unix_timestamp", since); err != nil {
+		return r, err
+	}
+	if r.Until, err = bound("until_unix_timestamp", until); err != nil {
+		return r, err
+	}
+	if since != nil && until != nil && *since > *until {
+		return r, status.Error(codes.InvalidArgument, "since_unix_timestamp must not be after until_unix_timestamp")
+	}
+	return r, nil
+}
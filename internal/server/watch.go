@@ -0,0 +1,81 @@
+package server
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"explore_service/internal/storage"
+	explorepb "explore_service/proto"
+)
+
+// WatchLikedYou streams changes to the actors who like the recipient
+// until the client disconnects.  Changes are read from the store in
+// batches of pageSize after the last sequence number sent; store
+// notifications only signal that there may be more to read.  A client
+// that reads slowly therefore blocks its own Send calls without
+// buffering changes in the server, and coalesced notifications are
+// caught up by the next read.
+func (s *ExploreServer) WatchLikedYou(req *explorepb.WatchLikedYouRequest, stream explorepb.ExploreService_WatchLikedYouServer) error {
+	if err := validateUserID("recipient_user_id", req.GetRecipientUserId()); err != nil {
+		return err
+	}
+	var after int64
+	if req.ResumeToken != nil {
+		seq, err := storage.ParseResumeToken(req.GetResumeToken())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid resume_token")
+		}
+		after = seq
+	}
+	ctx := stream.Context()
+	recipientID := req.GetRecipientUserId()
+	// Subscribe before reading the starting position so that changes
+	// made in between are not missed.
+	changed, unsubscribe := s.store.SubscribeLikedYou(recipientID)
+	defer unsubscribe()
+	if req.ResumeToken == nil {
+		seq, err := s.store.LatestLikeChange(ctx, recipientID)
+		if err != nil {
+			return storageError("WatchLikedYou", err)
+		}
+		after = seq
+	}
+	for {
+		changes, err := s.store.ListLikeChanges(ctx, recipientID, after, s.pageSize)
+		if err != nil {
+			return storageError("WatchLikedYou", err)
+		}
+		for _, c := range changes {
+			if err := stream.Send(likeChangeResponse(c)); err != nil {
+				return err
+			}
+			after = c.Seq
+		}
+		if len(changes) == s.pageSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-changed:
+		}
+	}
+}
+
+// likeChangeResponse converts a like change into the wire response.
+func likeChangeResponse(c storage.LikeChange) *explorepb.WatchLikedYouResponse {
+	resp := &explorepb.WatchLikedYouResponse{ResumeToken: storage.EncodeResumeToken(c.Seq)}
+	if c.Liked {
+		resp.Change = &explorepb.WatchLikedYouResponse_Liker{Liker: &explorepb.ListLikedYouResponse_Liker{
+			ActorId:       c.ActorID,
+			UnixTimestamp: c.Unix,
+			SuperLike:     c.SuperLike,
+		}}
+	} else {
+		resp.Change = &explorepb.WatchLikedYouResponse_Removed{Removed: &explorepb.WatchLikedYouResponse_Removal{
+			ActorId:       c.ActorID,
+			UnixTimestamp: c.Unix,
+		}}
+	}
+	return resp
+}
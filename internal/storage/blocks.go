@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"explore_service/internal/outbox"
+)
+
+// pairBlocked reports whether either of the two users has blocked the
+// other.
+func pairBlocked(ctx context.Context, tx pgx.Tx, a, b string) (bool, error) {
+	const query = `
+SELECT EXISTS (SELECT 1 FROM blocked_pairs WHERE user_id = $1 AND other_user_id = $2);
+    `
+	var blocked bool
+	err := tx.QueryRow(ctx, query, a, b).Scan(&blocked)
+	return blocked, err
+}
+
+// pairLikes reads whether a likes b and whether b likes a.
+func pairLikes(ctx context.Context, tx pgx.Tx, a, b string) (aLikesB, bLikesA bool, err error) {
+	const query = `
+SELECT actor_user_id = $1
+FROM decisions
+WHERE liked_recipient
+  AND ((actor_user_id = $1 AND recipient_user_id = $2)
+    OR (actor_user_id = $2 AND recipient_user_id = $1));
+    `
+	rows, err := tx.Query(ctx, query, a, b)
+	if err != nil {
+		return false, false, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var fromA bool
+		if err := rows.Scan(&fromA); err != nil {
+			return false, false, err
+		}
+		if fromA {
+			aLikesB = true
+		} else {
+			bLikesA = true
+		}
+	}
+	return aLikesB, bLikesA, rows.Err()
+}
+
+// BlockUser blocks blockedID for the blocker in one transaction.  The
+// pair lock orders the block with decisions between the two users, so
+// a concurrent like either commits first and is hidden here, or sees
+// the block.  The first block of a pair removes the pair's likes from
+// the counters and deletes their match.
+func (s *Store) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	ctx = withOperation(ctx, "BlockUser")
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// If the transaction is still open, roll it back.
+		_ = tx.Rollback(ctx)
+	}()
+	if err := lockDecisions(ctx, tx, blockerID, []string{blockedID}); err != nil {
+		return err
+	}
+	wasBlocked, err := pairBlocked(ctx, tx, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	const insert = `
+INSERT INTO blocks (blocker_user_id, blocked_user_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (blocker_user_id, blocked_user_id) DO NOTHING;
+    `
+	if _, err := tx.Exec(ctx, insert, blockerID, blockedID); err != nil {
+		return err
+	}
+	if !wasBlocked {
+		const deleteMatch = `
+DELETE FROM matches
+WHERE (user_id = $1 AND matched_user_id = $2) OR (user_id = $2 AND matched_user_id = $1);
+        `
+		tag, err := tx.Exec(ctx, deleteMatch, blockerID, blockedID)
+		if err != nil {
+			return err
+		}
+		if err := s.updatePairVisibility(ctx, tx, blockerID, blockedID, -1, tag.RowsAffected() > 0, outbox.MatchRemoved); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// UnblockUser lifts the blocker's block of blockedID in one
+// transaction.  Once neither user blocks the other their likes count
+// again, and a mutual like gets a new match.
+func (s *Store) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	ctx = withOperation(ctx, "UnblockUser")
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// If the transaction is still open, roll it back.
+		_ = tx.Rollback(ctx)
+	}()
+	if err := lockDecisions(ctx, tx, blockerID, []string{blockedID}); err != nil {
+		return err
+	}
+	const remove = `
+DELETE FROM blocks
+WHERE blocker_user_id = $1 AND blocked_user_id = $2;
+    `
+	tag, err := tx.Exec(ctx, remove, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return tx.Commit(ctx)
+	}
+	stillBlocked, err := pairBlocked(ctx, tx, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	if !stillBlocked {
+		aLikesB, bLikesA, err := pairLikes(ctx, tx, blockerID, blockedID)
+		if err != nil {
+			return err
+		}
+		var matched bool
+		if aLikesB && bLikesA {
+			const insertMatch = `
+INSERT INTO matches (user_id, matched_user_id, created_at)
+VALUES ($1, $2, NOW()), ($2, $1, NOW())
+ON CONFLICT (user_id, matched_user_id) DO NOTHING;
+            `
+			tag, err := tx.Exec(ctx, insertMatch, blockerID, blockedID)
+			if err != nil {
+				return err
+			}
+			matched = tag.RowsAffected() > 0
+		}
+		if err := s.updatePairVisibility(ctx, tx, blockerID, blockedID, 1, matched, outbox.MatchCreated); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// updatePairVisibility updates the counters for the likes between a
+// and b being hidden (sign -1) or shown again (sign 1), and records
+// matchEvent if matched reports that their match changed.
+func (s *Store) updatePairVisibility(ctx context.Context, tx pgx.Tx, a, b string, sign int64, matched bool, matchEvent outbox.EventType) error {
+	aLikesB, bLikesA, err := pairLikes(ctx, tx, a, b)
+	if err != nil {
+		return err
+	}
+	_, aHidden, bHidden, err := pairVisibility(ctx, tx, a, b)
+	if err != nil {
+		return err
+	}
+	if err := updateCounters(ctx, tx, pairCounterDeltas(a, b, aLikesB, bLikesA, aHidden, bHidden, matched, sign)); err != nil {
+		return err
+	}
+	if !matched {
+		return nil
+	}
+	var at time.Time
+	if err := tx.QueryRow(ctx, `SELECT NOW();`).Scan(&at); err != nil {
+		return err
+	}
+	return enqueueEvents(ctx, tx, []outbox.Event{{Type: matchEvent, ActorID: a, RecipientID: b, OccurredAt: at}})
+}
+
+// ListBlocked returns the users the blocker has blocked, most recent
+// block first.  Pagination works in the same way as ListLikedYou.
+func (s *Store) ListBlocked(ctx context.Context, blockerID string, after *Cursor, limit int) ([]BlockedUser, *Cursor, error) {
+	ctx = withOperation(ctx, "ListBlocked")
+	const query = `
+SELECT blocked_user_id, created_at
+FROM blocks
+WHERE blocker_user_id = $1
+  AND (created_at, blocked_user_id) %[1]s ($2, $3)
+ORDER BY created_at %[2]s, blocked_user_id %[2]s
+LIMIT $4;
+    `
+	return listKeyset(ctx, s.pool, query, blockerID, after, limit, NewestFirst, func(rows pgx.Rows) (BlockedUser, Cursor, error) {
+		var r Cursor
+		err := rows.Scan(&r.UserID, &r.Timestamp)
+		return BlockedUser{UserID: r.UserID, Unix: unixSeconds(r.Timestamp)}, r, err
+	})
+}
+
+// blockedLocked reports whether either of the two users has blocked
+// the other.  The caller must hold the lock.
+func (s *MemoryStore) blockedLocked(a, b string) bool {
+	_, ab := s.blocks[a][b]
+	_, ba := s.blocks[b][a]
+	return ab || ba
+}
+
+// BlockUser blocks blockedID for the blocker; see Store.BlockUser.
+func (s *MemoryStore) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.blocks[blockerID][blockedID]; ok {
+		return nil
+	}
+	wasBlocked := s.blockedLocked(blockerID, blockedID)
+	byBlocker, ok := s.blocks[blockerID]
+	if !ok {
+		byBlocker = make(map[string]time.Time)
+		s.blocks[blockerID] = byBlocker
+	}
+	at := s.now()
+	byBlocker[blockedID] = at
+	if !wasBlocked {
+		matched := s.removeMatch(blockerID, blockedID)
+		s.updatePairVisibilityLocked(blockerID, blockedID, -1, matched, outbox.MatchRemoved, at)
+	}
+	return nil
+}
+
+// UnblockUser lifts the blocker's block of blockedID; see
+// Store.UnblockUser.
+func (s *MemoryStore) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.blocks[blockerID][blockedID]; !ok {
+		return nil
+	}
+	delete(s.blocks[blockerID], blockedID)
+	if len(s.blocks[blockerID]) == 0 {
+		delete(s.blocks, blockerID)
+	}
+	if s.blockedLocked(blockerID, blockedID) {
+		return nil
+	}
+	at := s.now()
+	var matched bool
+	if s.received[blockedID][blockerID].liked && s.received[blockerID][blockedID].liked {
+		matched = s.addMatch(blockerID, blockedID, at)
+	}
+	s.updatePairVisibilityLocked(blockerID, blockedID, 1, matched, outbox.MatchCreated, at)
+	return nil
+}
+
+// updatePairVisibilityLocked is Store.updatePairVisibility for a
+// MemoryStore.  The caller must hold the write lock.
+func (s *MemoryStore) updatePairVisibilityLocked(a, b string, sign int64, matched bool, matchEvent outbox.EventType, at time.Time) {
+	aLikesB, bLikesA := s.received[b][a].liked, s.received[a][b].liked
+	s.addCountersLocked(pairCounterDeltas(a, b, aLikesB, bLikesA, s.hidden[a], s.hidden[b], matched, sign))
+	if matched {
+		s.lastEventID++
+		s.outbox = append(s.outbox, outbox.Event{ID: s.lastEventID, Type: matchEvent, ActorID: a, RecipientID: b, OccurredAt: at})
+	}
+}
+
+// ListBlocked returns the users the blocker has blocked, most recent
+// block first, paginated like ListMatches.
+func (s *MemoryStore) ListBlocked(ctx context.Context, blockerID string, after *Cursor, limit int) ([]BlockedUser, *Cursor, error) {
+	if limit <= 0 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+	if err := checkOrder(after, NewestFirst); err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	s.mu.RLock()
+	rows := make([]Cursor, 0, len(s.blocks[blockerID]))
+	for blockedID, at := range s.blocks[blockerID] {
+		rows = append(rows, Cursor{Timestamp: at, UserID: blockedID})
+	}
+	s.mu.RUnlock()
+	page, next := paginate(rows, after, limit, NewestFirst)
+	blocked := make([]BlockedUser, len(page))
+	for i, r := range page {
+		blocked[i] = BlockedUser{UserID: r.UserID, Unix: unixSeconds(r.Timestamp)}
+	}
+	return blocked, next, nil
+}
@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// WithLikeCounters makes CountLikedYou, CountNewLikedYou and
+// GetLikeSummary read the user_like_counters table instead of counting
+// decisions.  Counts restricted to a time range always count
+// decisions, as the counters only hold totals.
+func WithLikeCounters() Option {
+	return func(s *Store) { s.useCounters = true }
+}
+
+// counterDelta is a change to one row of user_like_counters.
+type counterDelta struct {
+	userID                   string
+	likes, newLikes, matches int64
+}
+
+// counterDeltas returns the counter changes caused by a decision.
+// The recipient's likes and new likes follow the actor's decision; the
+// actor's new likes change when the actor starts or stops liking back
+// a recipient who likes them.  Decisions between blocked users change
+// nothing, and a hidden user's likes do not count for the other user.
+// The deltas are merged by mergeCounterDeltas.
+func counterDeltas(actorID, recipientID string, liked bool, change decisionChange) []counterDelta {
+	if change.blocked {
+		return nil
+	}
+	likedBack := change.likedBack
+	b := func(v bool) int64 {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	matches := b(change.matchCreated) - b(change.matchRemoved)
+	shown, shownBack := !change.actorHidden, likedBack && !change.recipientHidden
+	deltas := []counterDelta{
+		{
+			userID:   recipientID,
+			likes:    b(shown && liked) - b(shown && change.wasLiked),
+			newLikes: b(shown && liked && !likedBack) - b(shown && change.wasLiked && !likedBack),
+			matches:  matches,
+		},
+		{
+			userID:   actorID,
+			newLikes: b(shownBack && !liked) - b(shownBack && !change.wasLiked),
+			matches:  matches,
+		},
+	}
+	return mergeCounterDeltas(deltas)
+}
+
+// pairCounterDeltas returns the counter changes caused by the likes
+// between a and b being shown again (sign 1) or hidden by a block
+// (sign -1).  matched reports whether their match was created or
+// removed along with them.  aHidden and bHidden report whether either
+// user is hidden, in which case their likes are not counted either
+// way.
+func pairCounterDeltas(a, b string, aLikesB, bLikesA, aHidden, bHidden, matched bool, sign int64) []counterDelta {
+	v := func(c bool) int64 {
+		if c {
+			return sign
+		}
+		return 0
+	}
+	aShown, bShown := aLikesB && !aHidden, bLikesA && !bHidden
+	deltas := []counterDelta{
+		{userID: b, likes: v(aShown), newLikes: v(aShown && !bLikesA), matches: v(matched)},
+		{userID: a, likes: v(bShown), newLikes: v(bShown && !aLikesB), matches: v(matched)},
+	}
+	return mergeCounterDeltas(deltas)
+}
+
+// mergeCounterDeltas sums the deltas for each user and returns them in
+// user id order, omitting empty ones.  Writers lock counter rows in
+// this order, so transactions updating several rows cannot deadlock.
+func mergeCounterDeltas(deltas []counterDelta) []counterDelta {
+	byUser := make(map[string]counterDelta, len(deltas))
+	for _, d := range deltas {
+		m := byUser[d.userID]
+		m.userID = d.userID
+		m.likes += d.likes
+		m.newLikes += d.newLikes
+		m.matches += d.matches
+		byUser[d.userID] = m
+	}
+	merged := make([]counterDelta, 0, len(byUser))
+	for _, d := range byUser {
+		if d.likes != 0 || d.newLikes != 0 || d.matches != 0 {
+			merged = append(merged, d)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].userID < merged[j].userID })
+	return merged
+}
+
+// updateCounters applies deltas to user_like_counters inside tx.
+func updateCounters(ctx context.Context, tx pgx.Tx, deltas []counterDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	const query = `
+INSERT INTO user_like_counters (user_id, likes_received, new_likes, matches)
+SELECT * FROM unnest($1::text[], $2::bigint[], $3::bigint[], $4::bigint[])
+ON CONFLICT (user_id) DO UPDATE SET
+    likes_received = user_like_counters.likes_received + EXCLUDED.likes_received,
+    new_likes      = user_like_counters.new_likes + EXCLUDED.new_likes,
+    matches        = user_like_counters.matches + EXCLUDED.matches;
+    `
+	users := make([]string, len(deltas))
+	likes := make([]int64, len(deltas))
+	newLikes := make([]int64, len(deltas))
+	matches := make([]int64, len(deltas))
+	for i, d := range deltas {
+		users[i], likes[i], newLikes[i], matches[i] = d.userID, d.likes, d.newLikes, d.matches
+	}
+	_, err := tx.Exec(ctx, query, users, likes, newLikes, matches)
+	return err
+}
+
+// counters returns the user's row of user_like_counters, or zero
+// counts if the user has none.
+func (s *Store) counters(ctx context.Context, userID string) (LikeSummary, error) {
+	const query = `
+SELECT likes_received, new_likes, matches
+FROM user_like_counters
+WHERE user_id = $1;
+    `
+	var sum LikeSummary
+	err := s.pool.QueryRow(ctx, query, userID).Scan(&sum.Likes, &sum.NewLikes, &sum.Matches)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return LikeSummary{}, nil
+	}
+	return sum, err
+}
+
+// RepairLikeCounters recomputes user_like_counters from decisions and
+// matches and returns the number of rows written.  Writers that update
+// counters wait while the repair runs, so no delta is lost or applied
+// twice; decisions that do not change any counter are not blocked.
+func (s *Store) RepairLikeCounters(ctx context.Context) (int64, error) {
+	ctx = withOperation(ctx, "RepairLikeCounters")
+	var n int64
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// A writer that already updated counters holds a conflicting
+		// lock until it commits, so once this lock is granted every
+		// committed delta is visible to the recomputation below.
+		if _, err := tx.Exec(ctx, `LOCK TABLE user_like_counters IN EXCLUSIVE MODE;`); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM user_like_counters;`); err != nil {
+			return err
+		}
+		const recompute = `
+INSERT INTO user_like_counters (user_id, likes_received, new_likes, matches)
+SELECT user_id, SUM(likes), SUM(new_likes), SUM(matches)
+FROM (
+    SELECT d.recipient_user_id AS user_id, 1 AS likes, (r.actor_user_id IS NULL)::int AS new_likes, 0 AS matches
+    FROM decisions d
+    LEFT JOIN decisions r ON r.actor_user_id = d.recipient_user_id AND r.recipient_user_id = d.actor_user_id AND r.liked_recipient = TRUE
+    WHERE d.liked_recipient = TRUE
+      AND NOT EXISTS (SELECT 1 FROM blocked_pairs b WHERE b.user_id = d.recipient_user_id AND b.other_user_id = d.actor_user_id)
+      AND NOT EXISTS (SELECT 1 FROM user_status s WHERE s.user_id = d.actor_user_id AND s.hidden)
+    UNION ALL
+    SELECT user_id, 0, 0, 1
+    FROM matches
+) c
+GROUP BY user_id;
+        `
+		tag, err := tx.Exec(ctx, recompute)
+		n = tag.RowsAffected()
+		return err
+	})
+	return n, err
+}
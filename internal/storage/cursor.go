@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalidCursor is returned by ParseCursor when a pagination token
+// was not produced by Cursor.Encode or has been tampered with.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// cursorVersion is the first byte of every encoded cursor.  Bump it
+// when the layout changes so that old tokens are rejected rather than
+// misread.  Version 1 tokens, which predate Order, are still accepted
+// as NewestFirst cursors.
+const cursorVersion byte = 2
+
+// Order is the direction of a paginated listing.
+type Order byte
+
+const (
+	// NewestFirst lists rows by (timestamp, user id) descending.
+	NewestFirst Order = iota
+	// OldestFirst lists rows by (timestamp, user id) ascending.
+	OldestFirst
+)
+
+// precedes reports whether the row at a comes before the row at b.
+func (o Order) precedes(a, b Cursor) bool {
+	if o == OldestFirst {
+		a, b = b, a
+	}
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.After(b.Timestamp)
+	}
+	return a.UserID > b.UserID
+}
+
+// Cursor identifies a position in a paginated listing.  Listings are
+// ordered by (timestamp, user id), newest first unless Order says
+// otherwise: liker listings by (updated_at, actor_user_id) and match
+// listings by (created_at, matched_user_id).  A cursor selects the
+// rows strictly after the last row of the previous page, so pages stay
+// stable when rows are updated in between.  The order is part of the
+// cursor so that it cannot change in the middle of a listing.
+type Cursor struct {
+	Timestamp time.Time
+	UserID    string
+	Order     Order
+}
+
+// Encode returns the opaque, URL-safe token for the cursor.
+func (c Cursor) Encode() string {
+	buf := make([]byte, 10, 10+len(c.UserID))
+	buf[0] = cursorVersion
+	buf[1] = byte(c.Order)
+	binary.BigEndian.PutUint64(buf[2:10], uint64(c.Timestamp.UnixMicro()))
+	buf = append(buf, c.UserID...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// ParseCursor decodes a token produced by Cursor.Encode.
+func ParseCursor(token string) (Cursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) == 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	switch {
+	case buf[0] == 1 && len(buf) >= 9:
+		buf = buf[1:]
+	case buf[0] == cursorVersion && len(buf) >= 10 && Order(buf[1]) <= OldestFirst:
+		c.Order = Order(buf[1])
+		buf = buf[2:]
+	default:
+		return Cursor{}, ErrInvalidCursor
+	}
+	userID := buf[8:]
+	if !utf8.Valid(userID) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	c.Timestamp = time.UnixMicro(int64(binary.BigEndian.Uint64(buf[:8]))).UTC()
+	c.UserID = string(userID)
+	return c, nil
+}
+
+// checkOrder returns ErrInvalidCursor if after belongs to a listing in
+// a different order.
+func checkOrder(after *Cursor, order Order) error {
+	if after != nil && after.Order != order {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// paginate sorts rows in the given order and returns the first page
+// of at most limit rows after the cursor, together with the cursor for
+// the following page.  It is the in-memory equivalent of the keyset
+// queries used by Store.
+func paginate(rows []Cursor, after *Cursor, limit int, order Order) ([]Cursor, *Cursor) {
+	page := make([]Cursor, 0, len(rows))
+	for _, r := range rows {
+		if after == nil || order.precedes(*after, r) {
+			page = append(page, r)
+		}
+	}
+	sort.Slice(page, func(i, j int) bool { return order.precedes(page[i], page[j]) })
+	if len(page) <= limit {
+		return page, nil
+	}
+	page = page[:limit]
+	next := page[limit-1]
+	next.Order = order
+	return page, &next
+}
+
+// unixSeconds converts t to whole seconds since the Unix epoch,
+// rounding to the nearest second.
+func unixSeconds(t time.Time) uint64 {
+	return uint64((t.UnixMicro() + 500000) / 1000000)
+}
@@ -4,47 +4,87 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // Store provides methods to record and query user decisions.
 type Store struct {
-	pool *pgxpool.Pool
+	pool     *pgxpool.Pool
+	migrator *Migrator
+	// migrate controls whether NewStore applies pending migrations.
+	migrate bool
+	// useCounters makes the unbounded counts read
+	// user_like_counters; see WithLikeCounters.
+	useCounters bool
+	// superLikeQuota is the number of super likes an actor may make
+	// per period; see WithSuperLikeQuota.
+	superLikeQuota int
+	// passTTL is how long a pass keeps the recipient out of
+	// FilterUndecided results, or zero if passes never expire; see
+	// WithPassTTL.
+	passTTL time.Duration
+	// likes tracks the subscribers of SubscribeLikedYou.
+	likes likeHub
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithoutMigrations stops NewStore from applying pending migrations.
+// Use it when migrations are run separately, for example with the
+// "explore-service migrate" command.
+func WithoutMigrations() Option {
+	return func(s *Store) { s.migrate = false }
 }
 
 // NewStore constructs a new Store using the given pgx connection pool.
-func NewStore(ctx context.Context, pool *pgxpool.Pool) (*Store, error) {
-	s := &Store{pool: pool}
-	if err := s.migrate(ctx); err != nil {
-		return nil, fmt.Errorf("failed to migrate database: %w", err)
+// Pending migrations are applied unless WithoutMigrations is given.
+func NewStore(ctx context.Context, pool *pgxpool.Pool, opts ...Option) (*Store, error) {
+	s := &Store{pool: pool, migrate: true, superLikeQuota: DefaultSuperLikeQuota}
+	for _, opt := range opts {
+		opt(s)
+	}
+	m, err := NewMigrator(pool)
+	if err != nil {
+		return nil, err
+	}
+	s.migrator = m
+	s.likes.start = s.startListener
+	if s.migrate {
+		if _, err := m.Up(withOperation(ctx, "Migrate")); err != nil {
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
 	}
 	return s, nil
 }
 
-// migrate creates the decisions table if it does not already exist.
-func (s *Store) migrate(ctx context.Context) error {
-	const createTable = `
-CREATE TABLE IF NOT EXISTS decisions (
-    actor_user_id     TEXT    NOT NULL,
-    recipient_user_id TEXT    NOT NULL,
-    liked_recipient   BOOLEAN NOT NULL,
-    updated_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-    PRIMARY KEY (actor_user_id, recipient_user_id)
-);
-
-CREATE INDEX IF NOT EXISTS idx_decisions_recipient ON decisions (recipient_user_id);
-CREATE INDEX IF NOT EXISTS idx_decisions_updated_at ON decisions (updated_at DESC);
-CREATE INDEX IF NOT EXISTS idx_decisions_actor_recipient_liked ON decisions (recipient_user_id, liked_recipient);
-    `
-	_, err := s.pool.Exec(ctx, createTable)
-	return err
+// Ready reports whether the store can serve requests: the database
+// must answer a ping and every migration must have been applied.
+func (s *Store) Ready(ctx context.Context) error {
+	ctx = withOperation(ctx, "Ready")
+	if err := s.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("database ping failed: %w", err)
+	}
+	complete, err := s.migrator.Complete(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check migrations: %w", err)
+	}
+	if !complete {
+		return errors.New("database migrations are pending")
+	}
+	return nil
 }
 
-// PutDecision stores or updates a decision.  If liked is true the
-// actor has liked the recipient; if false the actor has passed.  The
-// call returns a boolean indicating whether the like is now mutual.
-func (s *Store) PutDecision(ctx context.Context, actorID, recipientID string, liked bool) (bool, error) {
+// PutDecision stores or updates a decision: a pass, a like or a super
+// like of the recipient.  The call returns a boolean indicating
+// whether the like is now mutual.
+func (s *Store) PutDecision(ctx context.Context, actorID, recipientID string, decision DecisionType) (bool, error) {
+	ctx = withOperation(ctx, "PutDecision")
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return false, err
@@ -53,135 +93,610 @@ func (s *Store) PutDecision(ctx context.Context, actorID, recipientID string, li
 		// If the transaction is still open, roll it back.
 		_ = tx.Rollback(ctx)
 	}()
+	change, err := s.putDecisionTx(ctx, tx, actorID, recipientID, decision)
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return change.mutual, nil
+}
+
+// PutDecisions stores the actor's decisions in order in a single
+// transaction.  The locks for every decision are taken up front, so
+// concurrent batches cannot deadlock.  Each decision is written in its
+// own savepoint: one that fails is rolled back and reported in its
+// result while the others are kept.  The like counters are updated
+// once for the whole batch.  If the returned error is non-nil the
+// transaction was rolled back and nothing was stored.
+func (s *Store) PutDecisions(ctx context.Context, actorID string, decisions []Decision) ([]DecisionResult, error) {
+	ctx = withOperation(ctx, "PutDecisions")
+	if len(decisions) == 0 {
+		return nil, nil
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// If the transaction is still open, roll it back.
+		_ = tx.Rollback(ctx)
+	}()
+	recipients := make([]string, len(decisions))
+	for i, d := range decisions {
+		recipients[i] = d.RecipientID
+	}
+	if err := lockDecisions(ctx, tx, actorID, recipients); err != nil {
+		return nil, err
+	}
+	results := make([]DecisionResult, len(decisions))
+	var deltas []counterDelta
+	for i, d := range decisions {
+		// Begin on a transaction creates a savepoint.
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		change, err := s.applyDecision(ctx, sp, actorID, d.RecipientID, d.Type)
+		if err == nil {
+			err = sp.Commit(ctx)
+		}
+		if err != nil {
+			if rbErr := sp.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				return nil, rbErr
+			}
+			results[i].Err = err
+			continue
+		}
+		results[i].Mutual = change.mutual
+		deltas = append(deltas, counterDeltas(actorID, d.RecipientID, d.Type.liked(), change)...)
+	}
+	if err := updateCounters(ctx, tx, mergeCounterDeltas(deltas)); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// decisionChange describes the effect of a decision written by
+// putDecisionTx.
+type decisionChange struct {
+	// wasLiked reports whether the previous decision for the pair, if
+	// any, was a like, and wasSuperLike whether it was a super like.
+	wasLiked, wasSuperLike bool
+	// likedBack reports whether the recipient likes the actor.
+	likedBack bool
+	// blocked reports whether either user has blocked the other.  The
+	// decision is then hidden from the recipient: it changes no
+	// counter, emits no like and cannot make a match.
+	blocked bool
+	// actorHidden and recipientHidden report whether either user is
+	// hidden.  A hidden user's likes are left out of the other user's
+	// counters and emit no like, but still make matches.
+	actorHidden, recipientHidden bool
+	// mutual reports whether both users now like each other.
+	mutual bool
+	// matchCreated and matchRemoved report changes to the matches
+	// table.
+	matchCreated, matchRemoved bool
+	// at is the time the decision was recorded.
+	at time.Time
+}
+
+// putDecisionTx writes a decision inside tx together with everything
+// derived from it: the history log, the matches table, the like
+// counters and the outbox.
+func (s *Store) putDecisionTx(ctx context.Context, tx pgx.Tx, actorID, recipientID string, decision DecisionType) (decisionChange, error) {
+	if err := lockDecisions(ctx, tx, actorID, []string{recipientID}); err != nil {
+		return decisionChange{}, err
+	}
+	change, err := s.applyDecision(ctx, tx, actorID, recipientID, decision)
+	if err != nil {
+		return change, err
+	}
+	return change, updateCounters(ctx, tx, counterDeltas(actorID, recipientID, decision.liked(), change))
+}
+
+// lockDecisions takes the locks needed to write the actor's decisions
+// on recipients.  The pair locks serialise writes to the same pair of
+// users; without them two users liking each other concurrently could
+// both miss the other's like and never create the match.  The
+// recipient locks serialise each recipient's history so that its event
+// ids are assigned in commit order; watchers resume from an event id
+// and would otherwise skip an event committed after a later one.
+//
+// The status locks on the actor and recipients are taken shared, so
+// that SetUserVisibility, which takes them exclusively, sees every
+// decision's counter changes made with the user's current visibility.
+//
+// Every transaction takes all of its pair locks, then all of its
+// recipient locks and then all of its status locks, each in sorted
+// order, so transactions writing many decisions cannot deadlock with
+// each other.  The only lock taken later is the actor's super like
+// lock, which applyDecision takes before any counter row is updated.
+func lockDecisions(ctx context.Context, tx pgx.Tx, actorID string, recipientIDs []string) error {
+	pairs := make([]string, len(recipientIDs))
+	for i, r := range recipientIDs {
+		pairs[i] = pairKey(actorID, r)
+	}
+	const lockPairs = `
+SELECT pg_advisory_xact_lock(hashtextextended(k, 0))
+FROM unnest($1::text[]) AS k;
+    `
+	if _, err := tx.Exec(ctx, lockPairs, sortedUnique(pairs)); err != nil {
+		return err
+	}
+	const lockRecipients = `
+SELECT pg_advisory_xact_lock($1, hashtext(r))
+FROM unnest($2::text[]) AS r;
+    `
+	if _, err := tx.Exec(ctx, lockRecipients, recipientLockClass, sortedUnique(recipientIDs)); err != nil {
+		return err
+	}
+	const lockStatus = `
+SELECT pg_advisory_xact_lock_shared($1, hashtext(u))
+FROM unnest($2::text[]) AS u;
+    `
+	_, err := tx.Exec(ctx, lockStatus, userStatusLockClass, sortedUnique(append([]string{actorID}, recipientIDs...)))
+	return err
+}
+
+// sortedUnique returns the distinct values of keys in ascending order.
+// unnest preserves the order of its array, so locks taken over the
+// result are acquired in this order.
+func sortedUnique(keys []string) []string {
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
+
+// applyDecision writes a decision inside tx, which must hold the locks
+// taken by lockDecisions.  A super like is first checked against the
+// actor's quota.  It does not update the like counters; the caller
+// applies counterDeltas for the returned change.
+func (s *Store) applyDecision(ctx context.Context, tx pgx.Tx, actorID, recipientID string, decision DecisionType) (decisionChange, error) {
+	var change decisionChange
+	if decision == SuperLike {
+		if err := checkSuperLikeQuota(ctx, tx, actorID, recipientID, s.superLikeQuota); err != nil {
+			return change, err
+		}
+	}
+	liked, superLike := decision.liked(), decision == SuperLike
+	// Read the current decisions in both directions: the actor's
+	// previous decision and whether the recipient likes the actor.
+	const current = `
+SELECT actor_user_id = $1, liked_recipient, super_like
+FROM decisions
+WHERE (actor_user_id = $1 AND recipient_user_id = $2)
+   OR (actor_user_id = $2 AND recipient_user_id = $1);
+    `
+	rows, err := tx.Query(ctx, current, actorID, recipientID)
+	if err != nil {
+		return change, err
+	}
+	for rows.Next() {
+		var own, l, super bool
+		if err := rows.Scan(&own, &l, &super); err != nil {
+			rows.Close()
+			return change, err
+		}
+		if own {
+			change.wasLiked, change.wasSuperLike = l, super
+		} else {
+			change.likedBack = l
+		}
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return change, rows.Err()
+	}
+	if change.blocked, change.actorHidden, change.recipientHidden, err = pairVisibility(ctx, tx, actorID, recipientID); err != nil {
+		return change, err
+	}
 	// Upsert the decision.  updated_at is set to NOW() on each write.
 	const upsert = `
-INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, updated_at)
-VALUES ($1, $2, $3, NOW())
+INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient, super_like, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
 ON CONFLICT (actor_user_id, recipient_user_id)
-DO UPDATE SET liked_recipient = EXCLUDED.liked_recipient, updated_at = EXCLUDED.updated_at;
+DO UPDATE SET liked_recipient = EXCLUDED.liked_recipient, super_like = EXCLUDED.super_like, updated_at = EXCLUDED.updated_at
+RETURNING updated_at;
     `
-	if _, err := tx.Exec(ctx, upsert, actorID, recipientID, liked); err != nil {
-		return false, err
+	if err := tx.QueryRow(ctx, upsert, actorID, recipientID, liked, superLike).Scan(&change.at); err != nil {
+		return change, err
 	}
-	var mutual bool
-	if liked {
-		// Check if the recipient has already liked the actor.
-		const query = `
-SELECT liked_recipient
-FROM decisions
-WHERE actor_user_id = $1 AND recipient_user_id = $2;
+	// Append the decision to the history log.  NOW() is the
+	// transaction time, so the event matches updated_at exactly.
+	const logEvent = `
+INSERT INTO decision_events (actor_user_id, recipient_user_id, liked_recipient, super_like, created_at)
+VALUES ($1, $2, $3, $4, NOW());
+    `
+	if _, err := tx.Exec(ctx, logEvent, actorID, recipientID, liked, superLike); err != nil {
+		return change, err
+	}
+	change.mutual = liked && change.likedBack && !change.blocked
+	// Keep the matches table in step: a mutual like creates the match
+	// (keeping the original time if it already exists) and a pass from
+	// either side removes it.  Blocked users have no match to remove.
+	if change.mutual {
+		const insertMatch = `
+INSERT INTO matches (user_id, matched_user_id, created_at)
+VALUES ($1, $2, NOW()), ($2, $1, NOW())
+ON CONFLICT (user_id, matched_user_id) DO NOTHING;
         `
-		var likedBack bool
-		err := tx.QueryRow(ctx, query, recipientID, actorID).Scan(&likedBack)
-		if err == nil && likedBack {
-			mutual = true
+		tag, err := tx.Exec(ctx, insertMatch, actorID, recipientID)
+		if err != nil {
+			return change, err
 		}
+		change.matchCreated = tag.RowsAffected() > 0
+	} else if !liked {
+		const deleteMatch = `
+DELETE FROM matches
+WHERE (user_id = $1 AND matched_user_id = $2) OR (user_id = $2 AND matched_user_id = $1);
+        `
+		tag, err := tx.Exec(ctx, deleteMatch, actorID, recipientID)
+		if err != nil {
+			return change, err
+		}
+		change.matchRemoved = tag.RowsAffected() > 0
 	}
-	if err := tx.Commit(ctx); err != nil {
-		return false, err
+	if err := enqueueEvents(ctx, tx, decisionEvents(actorID, recipientID, decision, change)); err != nil {
+		return change, err
+	}
+	if liked || change.wasLiked {
+		if err := notifyLikedYou(ctx, tx, recipientID); err != nil {
+			return change, err
+		}
 	}
-	return mutual, nil
+	return change, nil
 }
 
-// Liker represents a like from an actor to a recipient.  Unix
-// holds the seconds since the Unix epoch when the decision was last
-// updated.
-type Liker struct {
-	ActorID string
-	Unix    uint64
+// notifyLikedYou wakes the recipient's watchers once tx commits.
+func notifyLikedYou(ctx context.Context, tx pgx.Tx, recipientID string) error {
+	_, err := tx.Exec(ctx, `SELECT pg_notify($1, $2);`, likedYouChannel, recipientID)
+	return err
 }
 
-// ListLikedYou returns all actors who have liked the recipient.  The
-// results are paginated using offset and limit.
-func (s *Store) ListLikedYou(ctx context.Context, recipientID string, offset, limit int) ([]Liker, *string, error) {
-	if limit <= 0 {
-		return nil, nil, errors.New("limit must be positive")
+// pairKey returns a key identifying the unordered pair of users a and
+// b, used to lock the pair.
+func pairKey(a, b string) string {
+	if b < a {
+		a, b = b, a
 	}
+	return a + "\n" + b
+}
+
+// ListLikedYou returns all actors who have liked the recipient in the
+// given order.  Results are paginated with a keyset cursor: pass the
+// cursor returned by the previous call to fetch the next page.  The
+// returned cursor is nil once there are no further results.
+func (s *Store) ListLikedYou(ctx context.Context, recipientID string, window TimeRange, after *Cursor, limit int, order Order) ([]Liker, *Cursor, error) {
+	ctx = withOperation(ctx, "ListLikedYou")
 	const query = `
-SELECT actor_user_id, extract(epoch from updated_at)::bigint
+SELECT actor_user_id, super_like, updated_at
 FROM decisions
 WHERE recipient_user_id = $1 AND liked_recipient = TRUE
-ORDER BY updated_at DESC, actor_user_id ASC
-OFFSET $2 LIMIT $3;
+  AND updated_at >= $5 AND updated_at < $6
+  AND NOT EXISTS (SELECT 1 FROM blocked_pairs b WHERE b.user_id = $1 AND b.other_user_id = actor_user_id)
+  AND NOT EXISTS (SELECT 1 FROM user_status s WHERE s.user_id = actor_user_id AND s.hidden)
+  AND (updated_at, actor_user_id) %[1]s ($2, $3)
+ORDER BY updated_at %[2]s, actor_user_id %[2]s
+LIMIT $4;
     `
-	rows, err := s.pool.Query(ctx, query, recipientID, offset, limit)
+	return s.listLikers(ctx, query, recipientID, window, after, limit, order)
+}
+
+// ListNewLikedYou returns likes where the recipient hasn't yet liked
+// back.  This excludes mutual likes from the result set.  Pagination
+// works in the same way as ListLikedYou.
+func (s *Store) ListNewLikedYou(ctx context.Context, recipientID string, window TimeRange, after *Cursor, limit int, order Order) ([]Liker, *Cursor, error) {
+	ctx = withOperation(ctx, "ListNewLikedYou")
+	const query = `
+SELECT d.actor_user_id, d.super_like, d.updated_at
+FROM decisions d
+LEFT JOIN decisions r ON r.actor_user_id = d.recipient_user_id AND r.recipient_user_id = d.actor_user_id AND r.liked_recipient = TRUE
+WHERE d.recipient_user_id = $1 AND d.liked_recipient = TRUE AND r.actor_user_id IS NULL
+  AND d.updated_at >= $5 AND d.updated_at < $6
+  AND NOT EXISTS (SELECT 1 FROM blocked_pairs b WHERE b.user_id = $1 AND b.other_user_id = d.actor_user_id)
+  AND NOT EXISTS (SELECT 1 FROM user_status s WHERE s.user_id = d.actor_user_id AND s.hidden)
+  AND (d.updated_at, d.actor_user_id) %[1]s ($2, $3)
+ORDER BY d.updated_at %[2]s, d.actor_user_id %[2]s
+LIMIT $4;
+    `
+	return s.listLikers(ctx, query, recipientID, window, after, limit, order)
+}
+
+// listLikers runs one of the liker listing queries and converts the
+// rows to likers.  The queries take the bounds of window as $5 and $6.
+func (s *Store) listLikers(ctx context.Context, query, recipientID string, window TimeRange, after *Cursor, limit int, order Order) ([]Liker, *Cursor, error) {
+	since, until := window.bounds()
+	return listKeyset(ctx, s.pool, query, recipientID, after, limit, order, scanLiker, since, until)
+}
+
+// scanLiker reads a liker from a row holding its actor id, super_like
+// and updated_at.
+func scanLiker(rows pgx.Rows) (Liker, Cursor, error) {
+	var r Cursor
+	var superLike bool
+	err := rows.Scan(&r.UserID, &superLike, &r.Timestamp)
+	return Liker{ActorID: r.UserID, SuperLike: superLike, Unix: unixSeconds(r.Timestamp)}, r, err
+}
+
+// listKeyset runs a keyset paginated listing query.  The query takes
+// the owning user id, the keyset position (timestamp and user id) and
+// the row limit as parameters, followed by args.  It is a format
+// string whose first verb is replaced by the keyset comparison
+// operator and whose second by the sort direction for order.  scan
+// reads a row and returns it together with its keyset position.  One
+// row more than the limit is fetched to find out whether another page
+// exists.
+func listKeyset[T any](ctx context.Context, pool *pgxpool.Pool, query, id string, after *Cursor, limit int, order Order, scan func(pgx.Rows) (T, Cursor, error), args ...any) ([]T, *Cursor, error) {
+	if limit <= 0 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+	if err := checkOrder(after, order); err != nil {
+		return nil, nil, err
+	}
+	// The first page starts at +infinity (or -infinity when listing
+	// oldest first), which sorts after (before) every stored
+	// timestamp, so a single query serves every page.
+	from := pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	cmp, dir := "<", "DESC"
+	if order == OldestFirst {
+		from.InfinityModifier = pgtype.NegativeInfinity
+		cmp, dir = ">", "ASC"
+	}
+	query = fmt.Sprintf(query, cmp, dir)
+	fromUser := ""
+	if after != nil {
+		from = pgtype.Timestamptz{Time: after.Timestamp, Valid: true}
+		fromUser = after.UserID
+	}
+	rows, err := pool.Query(ctx, query, append([]any{id, from, fromUser, limit + 1}, args...)...)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer rows.Close()
-	likers := make([]Liker, 0)
+	page := make([]T, 0)
+	var last Cursor
+	var next *Cursor
 	for rows.Next() {
-		var l Liker
-		var ts int64
-		if err := rows.Scan(&l.ActorID, &ts); err != nil {
+		item, pos, err := scan(rows)
+		if err != nil {
 			return nil, nil, err
 		}
-		l.Unix = uint64(ts)
-		likers = append(likers, l)
+		if len(page) == limit {
+			last.Order = order
+			next = &last
+			break
+		}
+		page = append(page, item)
+		last = pos
 	}
 	if rows.Err() != nil {
 		return nil, nil, rows.Err()
 	}
-	var nextToken *string
-	if len(likers) == limit {
-		// Compute next offset token.
-		nextOffset := offset + limit
-		nt := fmt.Sprintf("%d", nextOffset)
-		nextToken = &nt
+	return page, next, nil
+}
+
+// CountLikedYou returns the number of actors who like the recipient,
+// counting only likes last updated within window.
+func (s *Store) CountLikedYou(ctx context.Context, recipientID string, window TimeRange) (uint64, error) {
+	ctx = withOperation(ctx, "CountLikedYou")
+	if s.useCounters && window == (TimeRange{}) {
+		sum, err := s.counters(ctx, recipientID)
+		return sum.Likes, err
 	}
-	return likers, nextToken, nil
+	const query = `
+SELECT COUNT(*)::bigint
+FROM decisions
+WHERE recipient_user_id = $1 AND liked_recipient = TRUE
+  AND updated_at >= $2 AND updated_at < $3
+  AND NOT EXISTS (SELECT 1 FROM blocked_pairs b WHERE b.user_id = $1 AND b.other_user_id = actor_user_id)
+  AND NOT EXISTS (SELECT 1 FROM user_status s WHERE s.user_id = actor_user_id AND s.hidden);
+    `
+	since, until := window.bounds()
+	var count uint64
+	err := s.pool.QueryRow(ctx, query, recipientID, since, until).Scan(&count)
+	return count, err
 }
 
-// ListNewLikedYou returns likes where the recipient hasn't yet liked
-// back.  This excludes mutual likes from the result set.  Offset
-// based pagination is used for simplicity.
-func (s *Store) ListNewLikedYou(ctx context.Context, recipientID string, offset, limit int) ([]Liker, *string, error) {
-	if limit <= 0 {
-		return nil, nil, errors.New("limit must be positive")
+// CountNewLikedYou returns the number of actors who like the recipient
+// and have not been liked back, using the same anti-join as
+// ListNewLikedYou.
+func (s *Store) CountNewLikedYou(ctx context.Context, recipientID string, window TimeRange) (uint64, error) {
+	ctx = withOperation(ctx, "CountNewLikedYou")
+	if s.useCounters && window == (TimeRange{}) {
+		sum, err := s.counters(ctx, recipientID)
+		return sum.NewLikes, err
 	}
 	const query = `
-SELECT d.actor_user_id, extract(epoch from d.updated_at)::bigint
+SELECT COUNT(*)::bigint
 FROM decisions d
 LEFT JOIN decisions r ON r.actor_user_id = d.recipient_user_id AND r.recipient_user_id = d.actor_user_id AND r.liked_recipient = TRUE
 WHERE d.recipient_user_id = $1 AND d.liked_recipient = TRUE AND r.actor_user_id IS NULL
-ORDER BY d.updated_at DESC, d.actor_user_id ASC
-OFFSET $2 LIMIT $3;
+  AND d.updated_at >= $2 AND d.updated_at < $3
+  AND NOT EXISTS (SELECT 1 FROM blocked_pairs b WHERE b.user_id = $1 AND b.other_user_id = d.actor_user_id)
+  AND NOT EXISTS (SELECT 1 FROM user_status s WHERE s.user_id = d.actor_user_id AND s.hidden);
     `
-	rows, err := s.pool.Query(ctx, query, recipientID, offset, limit)
+	since, until := window.bounds()
+	var count uint64
+	err := s.pool.QueryRow(ctx, query, recipientID, since, until).Scan(&count)
+	return count, err
+}
+
+// GetLikeSummary returns the user's like, new like and match counts
+// from a single query.
+func (s *Store) GetLikeSummary(ctx context.Context, userID string) (LikeSummary, error) {
+	ctx = withOperation(ctx, "GetLikeSummary")
+	if s.useCounters {
+		return s.counters(ctx, userID)
+	}
+	const query = `
+SELECT
+    COUNT(*)::bigint,
+    COUNT(*) FILTER (WHERE r.actor_user_id IS NULL)::bigint,
+    (SELECT COUNT(*) FROM matches WHERE user_id = $1)::bigint
+FROM decisions d
+LEFT JOIN decisions r ON r.actor_user_id = d.recipient_user_id AND r.recipient_user_id = d.actor_user_id AND r.liked_recipient = TRUE
+WHERE d.recipient_user_id = $1 AND d.liked_recipient = TRUE
+  AND NOT EXISTS (SELECT 1 FROM blocked_pairs b WHERE b.user_id = $1 AND b.other_user_id = d.actor_user_id)
+  AND NOT EXISTS (SELECT 1 FROM user_status s WHERE s.user_id = d.actor_user_id AND s.hidden);
+    `
+	var sum LikeSummary
+	err := s.pool.QueryRow(ctx, query, userID).Scan(&sum.Likes, &sum.NewLikes, &sum.Matches)
+	return sum, err
+}
+
+// ListMatches returns the users the given user has matched with, most
+// recent match first.  Pagination works in the same way as
+// ListLikedYou.
+func (s *Store) ListMatches(ctx context.Context, userID string, after *Cursor, limit int) ([]Match, *Cursor, error) {
+	ctx = withOperation(ctx, "ListMatches")
+	const query = `
+SELECT matched_user_id, created_at
+FROM matches
+WHERE user_id = $1
+  AND (created_at, matched_user_id) %[1]s ($2, $3)
+ORDER BY created_at %[2]s, matched_user_id %[2]s
+LIMIT $4;
+    `
+	return listKeyset(ctx, s.pool, query, userID, after, limit, NewestFirst, func(rows pgx.Rows) (Match, Cursor, error) {
+		var r Cursor
+		err := rows.Scan(&r.UserID, &r.Timestamp)
+		return Match{UserID: r.UserID, Unix: unixSeconds(r.Timestamp)}, r, err
+	})
+}
+
+// GetDecision returns the actor's current decision on the recipient,
+// read by primary key.  ok is false if the actor has not decided.
+func (s *Store) GetDecision(ctx context.Context, actorID, recipientID string) (StoredDecision, bool, error) {
+	ctx = withOperation(ctx, "GetDecision")
+	const query = `
+SELECT liked_recipient, super_like, updated_at
+FROM decisions
+WHERE actor_user_id = $1 AND recipient_user_id = $2;
+    `
+	d := StoredDecision{RecipientID: recipientID}
+	var at time.Time
+	err := s.pool.QueryRow(ctx, query, actorID, recipientID).Scan(&d.Liked, &d.SuperLike, &at)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return StoredDecision{}, false, nil
+	}
 	if err != nil {
-		return nil, nil, err
+		return StoredDecision{}, false, err
+	}
+	d.Unix = unixSeconds(at)
+	return d, true, nil
+}
+
+// ListMyDecisions returns the actor's decisions that match filter,
+// most recently updated first.  The listing is served by the
+// actor-leading keyset index.  Pagination works in the same way as
+// ListLikedYou.
+func (s *Store) ListMyDecisions(ctx context.Context, actorID string, filter DecisionFilter, after *Cursor, limit int) ([]StoredDecision, *Cursor, error) {
+	ctx = withOperation(ctx, "ListMyDecisions")
+	const query = `
+SELECT recipient_user_id, liked_recipient, super_like, updated_at
+FROM decisions
+WHERE actor_user_id = $1
+  AND ($5::boolean IS NULL OR liked_recipient = $5)
+  AND (updated_at, recipient_user_id) %[1]s ($2, $3)
+ORDER BY updated_at %[2]s, recipient_user_id %[2]s
+LIMIT $4;
+    `
+	return listKeyset(ctx, s.pool, query, actorID, after, limit, NewestFirst, func(rows pgx.Rows) (StoredDecision, Cursor, error) {
+		var d StoredDecision
+		var r Cursor
+		err := rows.Scan(&r.UserID, &d.Liked, &d.SuperLike, &r.Timestamp)
+		d.RecipientID, d.Unix = r.UserID, unixSeconds(r.Timestamp)
+		return d, r, err
+	}, filter.liked())
+}
+
+// FilterUndecided returns the candidates the actor has not decided on
+// and has no block with, in the order given and without duplicates.
+// Passes older than the pass TTL count as undecided.  The decided and
+// blocked candidates are found with one primary key lookup over all of
+// them in each table.
+func (s *Store) FilterUndecided(ctx context.Context, actorID string, candidateIDs []string) ([]string, error) {
+	ctx = withOperation(ctx, "FilterUndecided")
+	if len(candidateIDs) == 0 {
+		return nil, nil
+	}
+	const query = `
+SELECT recipient_user_id
+FROM decisions
+WHERE actor_user_id = $1 AND recipient_user_id = ANY($2)
+  AND (liked_recipient OR $3::float8 = 0 OR updated_at > NOW() - make_interval(secs => $3::float8))
+UNION
+SELECT other_user_id
+FROM blocked_pairs
+WHERE user_id = $1 AND other_user_id = ANY($2);
+    `
+	rows, err := s.pool.Query(ctx, query, actorID, candidateIDs, s.passTTL.Seconds())
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
-	likers := make([]Liker, 0)
+	decided := make(map[string]bool)
 	for rows.Next() {
-		var l Liker
-		var ts int64
-		if err := rows.Scan(&l.ActorID, &ts); err != nil {
-			return nil, nil, err
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
 		}
-		l.Unix = uint64(ts)
-		likers = append(likers, l)
+		decided[id] = true
 	}
 	if rows.Err() != nil {
-		return nil, nil, rows.Err()
+		return nil, rows.Err()
 	}
-	var nextToken *string
-	if len(likers) == limit {
-		nextOffset := offset + limit
-		nt := fmt.Sprintf("%d", nextOffset)
-		nextToken = &nt
+	return undecided(candidateIDs, func(id string) bool { return decided[id] }), nil
+}
+
+// undecided returns the candidates for which decided is false, in
+// order and without duplicates.
+func undecided(candidateIDs []string, decided func(string) bool) []string {
+	seen := make(map[string]bool, len(candidateIDs))
+	result := make([]string, 0, len(candidateIDs))
+	for _, id := range candidateIDs {
+		if seen[id] || decided(id) {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
 	}
-	return likers, nextToken, nil
+	return result
 }
 
-// CountLikedYou returns the number of actors who like the recipient.
-func (s *Store) CountLikedYou(ctx context.Context, recipientID string) (uint64, error) {
+// GetDecisionHistory returns every decision the actor has recorded
+// for the recipient, oldest first.
+func (s *Store) GetDecisionHistory(ctx context.Context, actorID, recipientID string) ([]DecisionEvent, error) {
+	ctx = withOperation(ctx, "GetDecisionHistory")
 	const query = `
-SELECT COUNT(*)::bigint
-FROM decisions
-WHERE recipient_user_id = $1 AND liked_recipient = TRUE;
+SELECT liked_recipient, super_like, created_at
+FROM decision_events
+WHERE actor_user_id = $1 AND recipient_user_id = $2 AND NOT rewind AND NOT rewound
+ORDER BY id;
     `
-	var count uint64
-	err := s.pool.QueryRow(ctx, query, recipientID).Scan(&count)
-	return count, err
+	rows, err := s.pool.Query(ctx, query, actorID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	events := make([]DecisionEvent, 0)
+	for rows.Next() {
+		var e DecisionEvent
+		var createdAt time.Time
+		if err := rows.Scan(&e.Liked, &e.SuperLike, &createdAt); err != nil {
+			return nil, err
+		}
+		e.Unix = unixSeconds(createdAt)
+		events = append(events, e)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return events, nil
 }
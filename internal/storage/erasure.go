@@ -0,0 +1,487 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"explore_service/internal/outbox"
+)
+
+// Erasure reports what DeleteUserData deleted: the decisions and
+// decision events made by or on the user, the user's matches and the
+// blocks in either direction.  Unix holds the seconds since the Unix
+// epoch when the erasure completed.
+type Erasure struct {
+	Decisions, DecisionEvents, Matches, Blocks int64
+	Unix                                       uint64
+}
+
+// add accumulates the rows deleted by one batch.
+func (e *Erasure) add(o Erasure) {
+	e.Decisions += o.Decisions
+	e.DecisionEvents += o.DecisionEvents
+	e.Matches += o.Matches
+	e.Blocks += o.Blocks
+}
+
+// memoryErasure is an entry of a MemoryStore's erasure log.
+type memoryErasure struct {
+	userID  string
+	erasure Erasure
+}
+
+// ExportedDecisionEvent is an entry of a user's decision history in a
+// data export.  Seq increases with every entry of the history.
+type ExportedDecisionEvent struct {
+	Seq         int64
+	RecipientID string
+	Liked       bool
+	SuperLike   bool
+	Unix        uint64
+}
+
+// UserDataExport is one batch of the data exported by ExportUserData.
+// Exactly one field is set in each batch.
+type UserDataExport struct {
+	Summary   *LikeSummary
+	Decisions []StoredDecision
+	History   []ExportedDecisionEvent
+	Matches   []Match
+	Blocked   []BlockedUser
+}
+
+// exportSource is what exportUserData reads an export from.  Store and
+// MemoryStore implement it.
+type exportSource interface {
+	GetLikeSummary(ctx context.Context, userID string) (LikeSummary, error)
+	ListMyDecisions(ctx context.Context, actorID string, filter DecisionFilter, after *Cursor, limit int) ([]StoredDecision, *Cursor, error)
+	ListMatches(ctx context.Context, userID string, after *Cursor, limit int) ([]Match, *Cursor, error)
+	ListBlocked(ctx context.Context, blockerID string, after *Cursor, limit int) ([]BlockedUser, *Cursor, error)
+	// listHistory returns up to limit entries of the actor's decision
+	// history with a sequence number greater than after, oldest first.
+	listHistory(ctx context.Context, actorID string, after int64, limit int) ([]ExportedDecisionEvent, error)
+}
+
+// exportUserData implements ExportUserData for both stores.  Every
+// batch is read by its own query, so nothing is locked while yield
+// runs.
+func exportUserData(ctx context.Context, src exportSource, userID string, batchSize int, yield func(UserDataExport) error) error {
+	if batchSize <= 0 {
+		return errors.New("batch size must be positive")
+	}
+	sum, err := src.GetLikeSummary(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := yield(UserDataExport{Summary: &sum}); err != nil {
+		return err
+	}
+	if err := exportPages(ctx, batchSize, func(after *Cursor, limit int) (*Cursor, error) {
+		page, next, err := src.ListMyDecisions(ctx, userID, AllDecisions, after, limit)
+		if err == nil && len(page) > 0 {
+			err = yield(UserDataExport{Decisions: page})
+		}
+		return next, err
+	}); err != nil {
+		return err
+	}
+	for after := int64(0); ; {
+		page, err := src.listHistory(ctx, userID, after, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+		if err := yield(UserDataExport{History: page}); err != nil {
+			return err
+		}
+		after = page[len(page)-1].Seq
+	}
+	if err := exportPages(ctx, batchSize, func(after *Cursor, limit int) (*Cursor, error) {
+		page, next, err := src.ListMatches(ctx, userID, after, limit)
+		if err == nil && len(page) > 0 {
+			err = yield(UserDataExport{Matches: page})
+		}
+		return next, err
+	}); err != nil {
+		return err
+	}
+	return exportPages(ctx, batchSize, func(after *Cursor, limit int) (*Cursor, error) {
+		page, next, err := src.ListBlocked(ctx, userID, after, limit)
+		if err == nil && len(page) > 0 {
+			err = yield(UserDataExport{Blocked: page})
+		}
+		return next, err
+	})
+}
+
+// exportPages calls page with successive cursors until it returns the
+// last page.
+func exportPages(ctx context.Context, batchSize int, page func(after *Cursor, limit int) (*Cursor, error)) error {
+	var after *Cursor
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		next, err := page(after, batchSize)
+		if err != nil || next == nil {
+			return err
+		}
+		after = next
+	}
+}
+
+// ExportUserData passes everything stored about the user to yield in
+// batches of up to batchSize entries: the like summary, the user's
+// current decisions, their decision history, matches and blocks.  Each
+// batch is read by a separate query, so a large export holds no locks
+// and no long-running snapshot.  The export is therefore not a single
+// point-in-time view if the user keeps making decisions meanwhile.
+func (s *Store) ExportUserData(ctx context.Context, userID string, batchSize int, yield func(UserDataExport) error) error {
+	ctx = withOperation(ctx, "ExportUserData")
+	return exportUserData(ctx, s, userID, batchSize, yield)
+}
+
+// listHistory returns a page of the actor's decision history for
+// ExportUserData.  The sequence number is the decision_events id.
+func (s *Store) listHistory(ctx context.Context, actorID string, after int64, limit int) ([]ExportedDecisionEvent, error) {
+	const query = `
+SELECT id, recipient_user_id, liked_recipient, super_like, created_at
+FROM decision_events
+WHERE actor_user_id = $1 AND NOT rewind AND NOT rewound AND id > $2
+ORDER BY id
+LIMIT $3;
+    `
+	rows, err := s.pool.Query(ctx, query, actorID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []ExportedDecisionEvent
+	for rows.Next() {
+		var e ExportedDecisionEvent
+		var createdAt time.Time
+		if err := rows.Scan(&e.Seq, &e.RecipientID, &e.Liked, &e.SuperLike, &createdAt); err != nil {
+			return nil, err
+		}
+		e.Unix = unixSeconds(createdAt)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// DeleteUserData deletes every row about the user: their decisions and
+// the decisions on them with the history of both, their matches,
+// blocks, idempotency keys, visibility and like counters.  The other
+// users' like counters are corrected as if the user had withdrawn
+// their likes.
+//
+// The rows are deleted batchSize other users at a time, each batch in
+// a short transaction that takes the same pair locks as PutDecision,
+// so the erasure never blocks decisions for long.  A decision made
+// while the erasure runs is deleted by a later batch; the erasure only
+// completes once a full pass finds nothing left.  It is recorded in
+// erasure_log when it starts and completed at the end, together with a
+// UserDataDeleted event for downstream systems.  Calling it again for
+// the same user is harmless.
+func (s *Store) DeleteUserData(ctx context.Context, userID string, batchSize int) (Erasure, error) {
+	ctx = withOperation(ctx, "DeleteUserData")
+	if batchSize <= 0 {
+		return Erasure{}, errors.New("batch size must be positive")
+	}
+	var logID int64
+	if err := s.pool.QueryRow(ctx, `INSERT INTO erasure_log (user_id) VALUES ($1) RETURNING id;`, userID).Scan(&logID); err != nil {
+		return Erasure{}, err
+	}
+	var erasure Erasure
+	// after is the last other user handled by the current pass.  A
+	// pass ends when no other user follows it; a new pass then starts
+	// from the beginning to pick up rows written meanwhile.
+	var after string
+	for {
+		others, err := s.erasureCounterparts(ctx, userID, after, batchSize)
+		if err != nil {
+			return erasure, err
+		}
+		if len(others) == 0 {
+			if after == "" {
+				break
+			}
+			after = ""
+			continue
+		}
+		batch, err := s.erasePairs(ctx, userID, others)
+		if err != nil {
+			return erasure, err
+		}
+		erasure.add(batch)
+		after = others[len(others)-1]
+	}
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM user_like_counters WHERE user_id = $1;`, userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM user_status WHERE user_id = $1;`, userID); err != nil {
+			return err
+		}
+		const deleteKeys = `
+DELETE FROM idempotency_keys
+WHERE actor_user_id = $1 OR recipient_user_id = $1;
+        `
+		if _, err := tx.Exec(ctx, deleteKeys, userID); err != nil {
+			return err
+		}
+		const complete = `
+UPDATE erasure_log
+SET completed_at = NOW(), decisions = $2, decision_events = $3, matches = $4, blocks = $5
+WHERE id = $1
+RETURNING completed_at;
+        `
+		var completedAt time.Time
+		if err := tx.QueryRow(ctx, complete, logID, erasure.Decisions, erasure.DecisionEvents, erasure.Matches, erasure.Blocks).Scan(&completedAt); err != nil {
+			return err
+		}
+		erasure.Unix = unixSeconds(completedAt)
+		return enqueueEvents(ctx, tx, []outbox.Event{{Type: outbox.UserDataDeleted, ActorID: userID, OccurredAt: completedAt}})
+	})
+	return erasure, err
+}
+
+// erasureCounterparts returns up to limit users after the given one,
+// in order, that the user has a decision, decision event, match or
+// block with.  Every decision has at least one event, so the events
+// find the decisions on the user without an index on their recipient.
+func (s *Store) erasureCounterparts(ctx context.Context, userID, after string, limit int) ([]string, error) {
+	const query = `
+SELECT other_user_id
+FROM (
+    SELECT recipient_user_id FROM decisions WHERE actor_user_id = $1
+    UNION
+    SELECT recipient_user_id FROM decision_events WHERE actor_user_id = $1
+    UNION
+    SELECT actor_user_id FROM decision_events WHERE recipient_user_id = $1
+    UNION
+    SELECT matched_user_id FROM matches WHERE user_id = $1
+    UNION
+    SELECT other_user_id FROM blocked_pairs WHERE user_id = $1
+) AS o (other_user_id)
+WHERE other_user_id > $2
+ORDER BY other_user_id
+LIMIT $3;
+    `
+	rows, err := s.pool.Query(ctx, query, userID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// erasePairs deletes everything between the user and others in one
+// transaction and returns the number of rows deleted.
+func (s *Store) erasePairs(ctx context.Context, userID string, others []string) (Erasure, error) {
+	var erased Erasure
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if err := lockDecisions(ctx, tx, userID, others); err != nil {
+			return err
+		}
+		// The likes each way, whether the pair is blocked and whether
+		// either user is hidden decide how the other users' counters
+		// change.
+		const state = `
+SELECT o,
+       EXISTS (SELECT 1 FROM decisions WHERE actor_user_id = $1 AND recipient_user_id = o AND liked_recipient),
+       EXISTS (SELECT 1 FROM decisions WHERE actor_user_id = o AND recipient_user_id = $1 AND liked_recipient),
+       EXISTS (SELECT 1 FROM blocked_pairs WHERE user_id = $1 AND other_user_id = o),
+       EXISTS (SELECT 1 FROM user_status WHERE user_id = $1 AND hidden),
+       EXISTS (SELECT 1 FROM user_status WHERE user_id = o AND hidden)
+FROM unnest($2::text[]) AS o;
+        `
+		type pairState struct {
+			other                          string
+			userLikes, otherLikes, blocked bool
+			userHidden, otherHidden        bool
+		}
+		rows, err := tx.Query(ctx, state, userID, others)
+		if err != nil {
+			return err
+		}
+		states, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (pairState, error) {
+			var p pairState
+			err := row.Scan(&p.other, &p.userLikes, &p.otherLikes, &p.blocked, &p.userHidden, &p.otherHidden)
+			return p, err
+		})
+		if err != nil {
+			return err
+		}
+		const deleteMatches = `
+DELETE FROM matches
+WHERE (user_id = $1 AND matched_user_id = ANY($2)) OR (user_id = ANY($2) AND matched_user_id = $1)
+RETURNING matched_user_id;
+        `
+		rows, err = tx.Query(ctx, deleteMatches, userID, others)
+		if err != nil {
+			return err
+		}
+		matchedIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return err
+		}
+		// Each match has a row under both users; the rows under the
+		// other users name the user being erased.
+		matched := make(map[string]bool)
+		for _, id := range matchedIDs {
+			if id != userID {
+				matched[id] = true
+			}
+		}
+		erased.Matches = int64(len(matched))
+		var deltas []counterDelta
+		for _, p := range states {
+			if p.blocked {
+				// The pair's likes are already hidden from the counters.
+				continue
+			}
+			for _, d := range pairCounterDeltas(userID, p.other, p.userLikes, p.otherLikes, p.userHidden, p.otherHidden, matched[p.other], -1) {
+				// The user's own row is deleted at the end.
+				if d.userID != userID {
+					deltas = append(deltas, d)
+				}
+			}
+		}
+		deletes := []struct {
+			query string
+			n     *int64
+		}{
+			{`DELETE FROM decisions WHERE (actor_user_id = $1 AND recipient_user_id = ANY($2)) OR (actor_user_id = ANY($2) AND recipient_user_id = $1);`, &erased.Decisions},
+			{`DELETE FROM decision_events WHERE (actor_user_id = $1 AND recipient_user_id = ANY($2)) OR (actor_user_id = ANY($2) AND recipient_user_id = $1);`, &erased.DecisionEvents},
+			{`DELETE FROM blocks WHERE (blocker_user_id = $1 AND blocked_user_id = ANY($2)) OR (blocker_user_id = ANY($2) AND blocked_user_id = $1);`, &erased.Blocks},
+		}
+		for _, d := range deletes {
+			tag, err := tx.Exec(ctx, d.query, userID, others)
+			if err != nil {
+				return err
+			}
+			*d.n = tag.RowsAffected()
+		}
+		return updateCounters(ctx, tx, mergeCounterDeltas(deltas))
+	})
+	return erased, err
+}
+
+// ExportUserData passes everything stored about the user to yield in
+// batches; see Store.ExportUserData.  The lock is not held while yield
+// runs.
+func (s *MemoryStore) ExportUserData(ctx context.Context, userID string, batchSize int, yield func(UserDataExport) error) error {
+	return exportUserData(ctx, s, userID, batchSize, yield)
+}
+
+// listHistory is Store.listHistory for a MemoryStore.  The sequence
+// number of an entry is its position in the actor's swipes plus one.
+func (s *MemoryStore) listHistory(ctx context.Context, actorID string, after int64, limit int) ([]ExportedDecisionEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	// seen counts the entries of each pair's history already visited;
+	// swipes and the histories list a pair's decisions in the same
+	// order.
+	seen := make(map[memoryPair]int)
+	var events []ExportedDecisionEvent
+	for i, pair := range s.swipes[actorID] {
+		d := s.history[pair][seen[pair]]
+		seen[pair]++
+		if int64(i) < after {
+			continue
+		}
+		if len(events) == limit {
+			break
+		}
+		events = append(events, ExportedDecisionEvent{
+			Seq:         int64(i) + 1,
+			RecipientID: pair.recipientID,
+			Liked:       d.liked,
+			SuperLike:   d.superLike,
+			Unix:        unixSeconds(d.updatedAt),
+		})
+	}
+	return events, nil
+}
+
+// DeleteUserData deletes everything about the user under a single
+// acquisition of the lock; see Store.DeleteUserData.  batchSize is
+// ignored.
+func (s *MemoryStore) DeleteUserData(ctx context.Context, userID string, _ int) (Erasure, error) {
+	if err := ctx.Err(); err != nil {
+		return Erasure{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var erasure Erasure
+	others := make(map[string]bool)
+	for recipientID := range s.received {
+		if _, ok := s.received[recipientID][userID]; ok {
+			others[recipientID] = true
+		}
+	}
+	for actorID := range s.received[userID] {
+		others[actorID] = true
+	}
+	for otherID := range s.matches[userID] {
+		others[otherID] = true
+	}
+	for blockedID := range s.blocks[userID] {
+		others[blockedID] = true
+	}
+	for blockerID, byBlocker := range s.blocks {
+		if _, ok := byBlocker[userID]; ok {
+			others[blockerID] = true
+		}
+	}
+	for otherID := range others {
+		if !s.blockedLocked(userID, otherID) {
+			matched := s.removeMatch(userID, otherID)
+			if matched {
+				erasure.Matches++
+			}
+			s.addCountersLocked(pairCounterDeltas(userID, otherID, s.received[otherID][userID].liked, s.received[userID][otherID].liked, s.hidden[userID], s.hidden[otherID], matched, -1))
+		}
+		for _, pair := range []memoryPair{{actorID: userID, recipientID: otherID}, {actorID: otherID, recipientID: userID}} {
+			if _, ok := s.received[pair.recipientID][pair.actorID]; ok {
+				delete(s.received[pair.recipientID], pair.actorID)
+				erasure.Decisions++
+			}
+			erasure.DecisionEvents += int64(len(s.history[pair]))
+			delete(s.history, pair)
+		}
+		if _, ok := s.blocks[otherID][userID]; ok {
+			delete(s.blocks[otherID], userID)
+			erasure.Blocks++
+		}
+		s.swipes[otherID] = slices.DeleteFunc(s.swipes[otherID], func(p memoryPair) bool { return p.recipientID == userID })
+		s.likeChanges[otherID] = slices.DeleteFunc(s.likeChanges[otherID], func(c LikeChange) bool { return c.ActorID == userID })
+	}
+	erasure.Blocks += int64(len(s.blocks[userID]))
+	delete(s.blocks, userID)
+	delete(s.received, userID)
+	delete(s.swipes, userID)
+	delete(s.likeChanges, userID)
+	delete(s.counters, userID)
+	delete(s.hidden, userID)
+	for k, r := range s.idempotencyKeys {
+		if k.actorID == userID || r.recipientID == userID {
+			delete(s.idempotencyKeys, k)
+		}
+	}
+	at := s.now()
+	erasure.Unix = unixSeconds(at)
+	s.erasures = append(s.erasures, memoryErasure{userID: userID, erasure: erasure})
+	s.lastEventID++
+	s.outbox = append(s.outbox, outbox.Event{ID: s.lastEventID, Type: outbox.UserDataDeleted, ActorID: userID, OccurredAt: at})
+	return erasure, nil
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrIdempotencyKeyReused is returned by PutDecisionOnce when a key
+// that has not expired is presented with a different decision.
+var ErrIdempotencyKeyReused = errors.New("idempotency key already used for a different decision")
+
+// PutDecisionOnce is PutDecision for a request identified by key.  The
+// first call with a key stores the decision and remembers its result
+// for ttl.  Until then, calls with the same key and decision return
+// the remembered result without writing anything, so updated_at, the
+// history and the outbox are left untouched by retries.
+func (s *Store) PutDecisionOnce(ctx context.Context, key string, ttl time.Duration, actorID, recipientID string, decision DecisionType) (bool, error) {
+	ctx = withOperation(ctx, "PutDecisionOnce")
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		// If the transaction is still open, roll it back.
+		_ = tx.Rollback(ctx)
+	}()
+	// A retry targets the same pair, so the pair lock also orders it
+	// after the call it repeats.
+	if err := lockDecisions(ctx, tx, actorID, []string{recipientID}); err != nil {
+		return false, err
+	}
+	const lookup = `
+SELECT recipient_user_id, liked_recipient, super_like, mutual_likes
+FROM idempotency_keys
+WHERE actor_user_id = $1 AND idempotency_key = $2 AND expires_at > NOW();
+    `
+	var prevRecipient string
+	var prevLiked, prevSuperLike, prevMutual bool
+	err = tx.QueryRow(ctx, lookup, actorID, key).Scan(&prevRecipient, &prevLiked, &prevSuperLike, &prevMutual)
+	liked, superLike := decision.liked(), decision == SuperLike
+	switch {
+	case err == nil:
+		if prevRecipient != recipientID || prevLiked != liked || prevSuperLike != superLike {
+			return false, ErrIdempotencyKeyReused
+		}
+		return prevMutual, nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return false, err
+	}
+	change, err := s.applyDecision(ctx, tx, actorID, recipientID, decision)
+	if err != nil {
+		return false, err
+	}
+	if err := updateCounters(ctx, tx, counterDeltas(actorID, recipientID, liked, change)); err != nil {
+		return false, err
+	}
+	// An expired key that has not been collected yet is replaced.  A
+	// live one can only exist here if a call with the same key for
+	// another recipient committed since the lookup.
+	const remember = `
+INSERT INTO idempotency_keys (actor_user_id, idempotency_key, recipient_user_id, liked_recipient, super_like, mutual_likes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW() + make_interval(secs => $7))
+ON CONFLICT (actor_user_id, idempotency_key) DO UPDATE SET
+    recipient_user_id = EXCLUDED.recipient_user_id,
+    liked_recipient   = EXCLUDED.liked_recipient,
+    super_like        = EXCLUDED.super_like,
+    mutual_likes      = EXCLUDED.mutual_likes,
+    expires_at        = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= NOW();
+    `
+	tag, err := tx.Exec(ctx, remember, actorID, key, recipientID, liked, superLike, change.mutual, ttl.Seconds())
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, ErrIdempotencyKeyReused
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return change.mutual, nil
+}
+
+// DeleteExpiredIdempotencyKeys deletes expired idempotency keys and
+// returns how many were deleted.  Keys are deleted batchSize at a time,
+// each batch in its own short transaction, so collecting a large
+// backlog never holds locks for long.
+func (s *Store) DeleteExpiredIdempotencyKeys(ctx context.Context, batchSize int) (int64, error) {
+	ctx = withOperation(ctx, "DeleteExpiredIdempotencyKeys")
+	const query = `
+DELETE FROM idempotency_keys
+WHERE (actor_user_id, idempotency_key) IN (
+    SELECT actor_user_id, idempotency_key
+    FROM idempotency_keys
+    WHERE expires_at <= NOW()
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+);
+    `
+	var total int64
+	for {
+		tag, err := s.pool.Exec(ctx, query, batchSize)
+		if err != nil {
+			return total, err
+		}
+		n := tag.RowsAffected()
+		total += n
+		if n == 0 || n < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
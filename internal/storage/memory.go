@@ -0,0 +1,630 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"explore_service/internal/outbox"
+)
+
+// memoryDecision is a single decision held by MemoryStore.
+type memoryDecision struct {
+	liked, superLike bool
+	updatedAt        time.Time
+}
+
+// memoryIdempotencyKey identifies an idempotency key, which is scoped
+// to the actor.
+type memoryIdempotencyKey struct {
+	actorID, key string
+}
+
+// memoryIdempotentResult is the remembered result of a PutDecisionOnce
+// call.
+type memoryIdempotentResult struct {
+	recipientID string
+	decision    DecisionType
+	mutual      bool
+	expiresAt   time.Time
+}
+
+// memoryPair identifies a directed actor/recipient pair.
+type memoryPair struct {
+	actorID, recipientID string
+}
+
+// MemoryStore is an in-process DecisionStore.  It is safe for
+// concurrent use and mirrors the ordering and mutual-like semantics
+// of Store, which makes it suitable for unit tests and local demos
+// that should not depend on PostgreSQL.
+type MemoryStore struct {
+	mu sync.RWMutex
+	// received indexes decisions by recipient and then by actor so
+	// that the liked-you queries only touch a single recipient.
+	received map[string]map[string]memoryDecision
+	// matches holds the creation time of every match, indexed under
+	// both users like the matches table.
+	matches map[string]map[string]time.Time
+	// history holds the decision log of every actor/recipient pair.
+	history map[memoryPair][]memoryDecision
+	// swipes holds the pairs of each actor's decisions that have not
+	// been rewound, oldest first, so RewindDecision finds the latest.
+	swipes map[string][]memoryPair
+	// counters holds the per-user counts that the user_like_counters
+	// table holds for Store.  The unbounded counts read them.
+	counters map[string]LikeSummary
+	// idempotencyKeys holds the results remembered by
+	// PutDecisionOnce, including expired ones not yet deleted.
+	idempotencyKeys map[memoryIdempotencyKey]memoryIdempotentResult
+	// blocks holds the creation time of every block, indexed by
+	// blocker and then by blocked user like the blocks table.
+	blocks map[string]map[string]time.Time
+	// hidden holds the users that are hidden, like the user_status
+	// rows with hidden set.
+	hidden map[string]bool
+	// erasures is the erasure log, one entry per DeleteUserData call.
+	erasures []memoryErasure
+	// superLikeQuota is the number of super likes an actor may make
+	// per period; see WithMemorySuperLikeQuota.
+	superLikeQuota int
+	// passTTL is how long a pass counts as a decision; see
+	// WithMemoryPassTTL.
+	passTTL time.Duration
+	// likeChanges holds the changes to every recipient's likers, in
+	// the order they were made.
+	likeChanges   map[string][]LikeChange
+	lastChangeSeq int64
+	likes         likeHub
+	// outbox holds the events that have not been drained yet.  Like
+	// the outbox table it grows until a relay drains it.
+	outbox      []outbox.Event
+	lastEventID int64
+	// drainMu serialises DrainOutbox calls.
+	drainMu sync.Mutex
+	// last is the most recent timestamp handed out by now.
+	last time.Time
+}
+
+// NewMemoryStore constructs an empty MemoryStore.
+func NewMemoryStore(opts ...MemoryOption) *MemoryStore {
+	s := &MemoryStore{
+		received:        make(map[string]map[string]memoryDecision),
+		matches:         make(map[string]map[string]time.Time),
+		history:         make(map[memoryPair][]memoryDecision),
+		swipes:          make(map[string][]memoryPair),
+		counters:        make(map[string]LikeSummary),
+		idempotencyKeys: make(map[memoryIdempotencyKey]memoryIdempotentResult),
+		blocks:          make(map[string]map[string]time.Time),
+		hidden:          make(map[string]bool),
+		superLikeQuota:  DefaultSuperLikeQuota,
+		likeChanges:     make(map[string][]LikeChange),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// now returns the timestamp for a write.  Timestamps are truncated to
+// microseconds to match PostgreSQL precision and are kept strictly
+// increasing so that sequential writes order the same way they do in
+// the database.  The caller must hold the write lock.
+func (s *MemoryStore) now() time.Time {
+	t := time.Now().UTC().Truncate(time.Microsecond)
+	if !t.After(s.last) {
+		t = s.last.Add(time.Microsecond)
+	}
+	s.last = t
+	return t
+}
+
+// Ready reports whether the store can serve requests.  A MemoryStore
+// is always ready.
+func (s *MemoryStore) Ready(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// PutDecision stores or updates a decision and reports whether the
+// like is now mutual.
+func (s *MemoryStore) PutDecision(ctx context.Context, actorID, recipientID string, decision DecisionType) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change, err := s.putDecisionLocked(actorID, recipientID, decision)
+	return change.mutual, err
+}
+
+// PutDecisionOnce is PutDecision for a request identified by key; see
+// Store.PutDecisionOnce.
+func (s *MemoryStore) PutDecisionOnce(ctx context.Context, key string, ttl time.Duration, actorID, recipientID string, decision DecisionType) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := memoryIdempotencyKey{actorID: actorID, key: key}
+	now := time.Now()
+	if prev, ok := s.idempotencyKeys[k]; ok && prev.expiresAt.After(now) {
+		if prev.recipientID != recipientID || prev.decision != decision {
+			return false, ErrIdempotencyKeyReused
+		}
+		return prev.mutual, nil
+	}
+	change, err := s.putDecisionLocked(actorID, recipientID, decision)
+	if err != nil {
+		return false, err
+	}
+	s.idempotencyKeys[k] = memoryIdempotentResult{
+		recipientID: recipientID,
+		decision:    decision,
+		mutual:      change.mutual,
+		expiresAt:   now.Add(ttl),
+	}
+	return change.mutual, nil
+}
+
+// DeleteExpiredIdempotencyKeys deletes expired idempotency keys and
+// returns how many were deleted.  batchSize is ignored as the whole
+// map is swept under one lock.
+func (s *MemoryStore) DeleteExpiredIdempotencyKeys(ctx context.Context, _ int) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	var n int64
+	for k, r := range s.idempotencyKeys {
+		if !r.expiresAt.After(now) {
+			delete(s.idempotencyKeys, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// PutDecisions stores the actor's decisions in order under a single
+// acquisition of the lock, so readers see all of them or none.
+func (s *MemoryStore) PutDecisions(ctx context.Context, actorID string, decisions []Decision) ([]DecisionResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(decisions) == 0 {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make([]DecisionResult, len(decisions))
+	for i, d := range decisions {
+		change, err := s.putDecisionLocked(actorID, d.RecipientID, d.Type)
+		results[i] = DecisionResult{Mutual: change.mutual, Err: err}
+	}
+	return results, nil
+}
+
+// putDecisionLocked writes a decision together with everything
+// derived from it, like Store.putDecisionTx.  The caller must hold the
+// write lock.
+func (s *MemoryStore) putDecisionLocked(actorID, recipientID string, decision DecisionType) (decisionChange, error) {
+	if decision == SuperLike {
+		if err := s.checkSuperLikeQuotaLocked(actorID, recipientID); err != nil {
+			return decisionChange{}, err
+		}
+	}
+	byActor, ok := s.received[recipientID]
+	if !ok {
+		byActor = make(map[string]memoryDecision)
+		s.received[recipientID] = byActor
+	}
+	liked := decision.liked()
+	change := decisionChange{at: s.now()}
+	change.wasLiked, change.wasSuperLike = byActor[actorID].liked, byActor[actorID].superLike
+	d := memoryDecision{liked: liked, superLike: decision == SuperLike, updatedAt: change.at}
+	byActor[actorID] = d
+	pair := memoryPair{actorID: actorID, recipientID: recipientID}
+	s.history[pair] = append(s.history[pair], d)
+	s.swipes[actorID] = append(s.swipes[actorID], pair)
+	change.likedBack = s.received[actorID][recipientID].liked
+	change.blocked = s.blockedLocked(actorID, recipientID)
+	change.actorHidden, change.recipientHidden = s.hidden[actorID], s.hidden[recipientID]
+	change.mutual = liked && change.likedBack && !change.blocked
+	if change.mutual {
+		change.matchCreated = s.addMatch(actorID, recipientID, change.at)
+	} else if !liked {
+		change.matchRemoved = s.removeMatch(actorID, recipientID)
+	}
+	s.recordChangeLocked(actorID, recipientID, d, change, decisionEvents(actorID, recipientID, decision, change))
+	return change, nil
+}
+
+// recordChangeLocked applies the effects of a change to the actor's
+// decision on the recipient that Store makes alongside the decision
+// itself: the like counters, the recipient's like changes and the
+// outbox.  d is the decision now in effect, the zero value if there is
+// none.  The caller must hold the write lock.
+func (s *MemoryStore) recordChangeLocked(actorID, recipientID string, d memoryDecision, change decisionChange, events []outbox.Event) {
+	s.addCountersLocked(counterDeltas(actorID, recipientID, d.liked, change))
+	if d.liked || change.wasLiked {
+		s.lastChangeSeq++
+		c := LikeChange{Seq: s.lastChangeSeq, ActorID: actorID, Liked: d.liked, SuperLike: d.superLike, Unix: unixSeconds(change.at)}
+		s.likeChanges[recipientID] = append(s.likeChanges[recipientID], c)
+		s.likes.notify(recipientID)
+	}
+	for _, e := range events {
+		s.lastEventID++
+		e.ID = s.lastEventID
+		s.outbox = append(s.outbox, e)
+	}
+}
+
+// addCountersLocked applies deltas to the counters.  The caller must
+// hold the write lock.
+func (s *MemoryStore) addCountersLocked(deltas []counterDelta) {
+	for _, delta := range deltas {
+		c := s.counters[delta.userID]
+		c.Likes = uint64(int64(c.Likes) + delta.likes)
+		c.NewLikes = uint64(int64(c.NewLikes) + delta.newLikes)
+		c.Matches = uint64(int64(c.Matches) + delta.matches)
+		s.counters[delta.userID] = c
+	}
+}
+
+// RewindDecision undoes the actor's latest decision made within
+// window; see Store.RewindDecision.
+func (s *MemoryStore) RewindDecision(ctx context.Context, actorID string, window time.Duration) (Rewind, error) {
+	if err := ctx.Err(); err != nil {
+		return Rewind{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	swipes := s.swipes[actorID]
+	if len(swipes) == 0 {
+		return Rewind{}, ErrNothingToRewind
+	}
+	pair := swipes[len(swipes)-1]
+	events := s.history[pair]
+	undone := events[len(events)-1]
+	now := time.Now()
+	if now.Sub(undone.updatedAt) > window || s.passExpired(undone, now) {
+		return Rewind{}, ErrNothingToRewind
+	}
+	s.swipes[actorID] = swipes[:len(swipes)-1]
+	s.history[pair] = events[:len(events)-1]
+	rewind := Rewind{RecipientID: pair.recipientID, Liked: undone.liked}
+	// restored is the decision now in effect, the zero value if the
+	// actor no longer has one.
+	var restored memoryDecision
+	if len(events) > 1 && !s.passExpired(events[len(events)-2], now) {
+		restored = events[len(events)-2]
+		s.received[pair.recipientID][actorID] = restored
+		rewind.Restored = &StoredDecision{RecipientID: pair.recipientID, Liked: restored.liked, SuperLike: restored.superLike, Unix: unixSeconds(restored.updatedAt)}
+	} else {
+		delete(s.received[pair.recipientID], actorID)
+	}
+	back := s.received[actorID][pair.recipientID]
+	change := decisionChange{wasLiked: undone.liked, wasSuperLike: undone.superLike, likedBack: back.liked, at: s.now()}
+	change.blocked = s.blockedLocked(actorID, pair.recipientID)
+	change.actorHidden, change.recipientHidden = s.hidden[actorID], s.hidden[pair.recipientID]
+	change.mutual = restored.liked && change.likedBack && !change.blocked
+	if change.mutual {
+		matchedAt := restored.updatedAt
+		if back.updatedAt.After(matchedAt) {
+			matchedAt = back.updatedAt
+		}
+		change.matchCreated = s.addMatch(actorID, pair.recipientID, matchedAt)
+	} else {
+		change.matchRemoved = s.removeMatch(actorID, pair.recipientID)
+	}
+	s.recordChangeLocked(actorID, pair.recipientID, restored, change, rewindEvents(actorID, pair.recipientID, change))
+	return rewind, nil
+}
+
+// addMatch records a match between a and b unless one exists and
+// reports whether it was created.  The caller must hold the write
+// lock.
+func (s *MemoryStore) addMatch(a, b string, at time.Time) bool {
+	if _, ok := s.matches[a][b]; ok {
+		return false
+	}
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		m, ok := s.matches[pair[0]]
+		if !ok {
+			m = make(map[string]time.Time)
+			s.matches[pair[0]] = m
+		}
+		m[pair[1]] = at
+	}
+	return true
+}
+
+// removeMatch deletes the match between a and b, if any, and reports
+// whether one existed.  The caller must hold the write lock.
+func (s *MemoryStore) removeMatch(a, b string) bool {
+	if _, ok := s.matches[a][b]; !ok {
+		return false
+	}
+	delete(s.matches[a], b)
+	delete(s.matches[b], a)
+	return true
+}
+
+// ListLikedYou returns all actors who have liked the recipient in the
+// given order, paginated with a keyset cursor like Store.
+func (s *MemoryStore) ListLikedYou(ctx context.Context, recipientID string, window TimeRange, after *Cursor, limit int, order Order) ([]Liker, *Cursor, error) {
+	return s.listLikers(ctx, recipientID, window, after, limit, order, false)
+}
+
+// ListNewLikedYou returns likes where the recipient hasn't yet liked
+// back.
+func (s *MemoryStore) ListNewLikedYou(ctx context.Context, recipientID string, window TimeRange, after *Cursor, limit int, order Order) ([]Liker, *Cursor, error) {
+	return s.listLikers(ctx, recipientID, window, after, limit, order, true)
+}
+
+// listLikers implements both list queries.  Blocked and hidden actors
+// are skipped, and when onlyNew is set, so are actors the recipient
+// has liked back.
+func (s *MemoryStore) listLikers(ctx context.Context, recipientID string, window TimeRange, after *Cursor, limit int, order Order, onlyNew bool) ([]Liker, *Cursor, error) {
+	if limit <= 0 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+	if err := checkOrder(after, order); err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	s.mu.RLock()
+	rows := make([]Cursor, 0, len(s.received[recipientID]))
+	superLikes := make(map[string]bool)
+	for actorID, d := range s.received[recipientID] {
+		if !d.liked || !window.contains(d.updatedAt) || s.blockedLocked(recipientID, actorID) || s.hidden[actorID] {
+			continue
+		}
+		if onlyNew {
+			if back, ok := s.received[actorID][recipientID]; ok && back.liked {
+				continue
+			}
+		}
+		rows = append(rows, Cursor{Timestamp: d.updatedAt, UserID: actorID})
+		superLikes[actorID] = d.superLike
+	}
+	s.mu.RUnlock()
+	page, next := paginate(rows, after, limit, order)
+	likers := make([]Liker, len(page))
+	for i, r := range page {
+		likers[i] = Liker{ActorID: r.UserID, SuperLike: superLikes[r.UserID], Unix: unixSeconds(r.Timestamp)}
+	}
+	return likers, next, nil
+}
+
+// CountLikedYou returns the number of actors who like the recipient,
+// counting only likes last updated within window.
+func (s *MemoryStore) CountLikedYou(ctx context.Context, recipientID string, window TimeRange) (uint64, error) {
+	return s.countLikers(ctx, recipientID, window, false)
+}
+
+// CountNewLikedYou returns the number of actors who like the recipient
+// and have not been liked back.
+func (s *MemoryStore) CountNewLikedYou(ctx context.Context, recipientID string, window TimeRange) (uint64, error) {
+	return s.countLikers(ctx, recipientID, window, true)
+}
+
+// countLikers implements both counts.  When onlyNew is set, actors the
+// recipient has liked back are skipped.  Unbounded counts are read from
+// the counters, like Store does with WithLikeCounters.
+func (s *MemoryStore) countLikers(ctx context.Context, recipientID string, window TimeRange, onlyNew bool) (uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if window == (TimeRange{}) {
+		c := s.counters[recipientID]
+		if onlyNew {
+			return c.NewLikes, nil
+		}
+		return c.Likes, nil
+	}
+	var count uint64
+	for actorID, d := range s.received[recipientID] {
+		if !d.liked || !window.contains(d.updatedAt) || s.blockedLocked(recipientID, actorID) || s.hidden[actorID] {
+			continue
+		}
+		if onlyNew && s.received[actorID][recipientID].liked {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// GetLikeSummary returns the user's like, new like and match counts.
+func (s *MemoryStore) GetLikeSummary(ctx context.Context, userID string) (LikeSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return LikeSummary{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.counters[userID], nil
+}
+
+// ListMatches returns the users the given user has matched with, most
+// recent match first, paginated like ListLikedYou.
+func (s *MemoryStore) ListMatches(ctx context.Context, userID string, after *Cursor, limit int) ([]Match, *Cursor, error) {
+	if limit <= 0 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+	if err := checkOrder(after, NewestFirst); err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	s.mu.RLock()
+	rows := make([]Cursor, 0, len(s.matches[userID]))
+	for matchedID, at := range s.matches[userID] {
+		rows = append(rows, Cursor{Timestamp: at, UserID: matchedID})
+	}
+	s.mu.RUnlock()
+	page, next := paginate(rows, after, limit, NewestFirst)
+	matches := make([]Match, len(page))
+	for i, r := range page {
+		matches[i] = Match{UserID: r.UserID, Unix: unixSeconds(r.Timestamp)}
+	}
+	return matches, next, nil
+}
+
+// GetDecision returns the actor's current decision on the recipient.
+// ok is false if the actor has not decided.
+func (s *MemoryStore) GetDecision(ctx context.Context, actorID, recipientID string) (StoredDecision, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return StoredDecision{}, false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	d, ok := s.received[recipientID][actorID]
+	if !ok {
+		return StoredDecision{}, false, nil
+	}
+	return StoredDecision{RecipientID: recipientID, Liked: d.liked, SuperLike: d.superLike, Unix: unixSeconds(d.updatedAt)}, true, nil
+}
+
+// ListMyDecisions returns the actor's decisions that match filter,
+// most recently updated first.  Decisions are indexed by recipient, so
+// this visits every recipient; that is fine for the tests and demos
+// MemoryStore serves.
+func (s *MemoryStore) ListMyDecisions(ctx context.Context, actorID string, filter DecisionFilter, after *Cursor, limit int) ([]StoredDecision, *Cursor, error) {
+	if limit <= 0 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+	if err := checkOrder(after, NewestFirst); err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var rows []Cursor
+	for recipientID, byActor := range s.received {
+		if d, ok := byActor[actorID]; ok && filter.matches(d.liked) {
+			rows = append(rows, Cursor{Timestamp: d.updatedAt, UserID: recipientID})
+		}
+	}
+	page, next := paginate(rows, after, limit, NewestFirst)
+	decisions := make([]StoredDecision, len(page))
+	for i, r := range page {
+		d := s.received[r.UserID][actorID]
+		decisions[i] = StoredDecision{RecipientID: r.UserID, Liked: d.liked, SuperLike: d.superLike, Unix: unixSeconds(r.Timestamp)}
+	}
+	return decisions, next, nil
+}
+
+// FilterUndecided returns the candidates the actor has not decided on
+// and has no block with, in the order given and without duplicates.
+// Passes older than the pass TTL count as undecided.
+func (s *MemoryStore) FilterUndecided(ctx context.Context, actorID string, candidateIDs []string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(candidateIDs) == 0 {
+		return nil, nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now()
+	return undecided(candidateIDs, func(id string) bool {
+		d, ok := s.received[id][actorID]
+		return ok && !s.passExpired(d, now) || s.blockedLocked(actorID, id)
+	}), nil
+}
+
+// GetDecisionHistory returns every decision the actor has recorded
+// for the recipient, oldest first.
+func (s *MemoryStore) GetDecisionHistory(ctx context.Context, actorID, recipientID string) ([]DecisionEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	log := s.history[memoryPair{actorID: actorID, recipientID: recipientID}]
+	events := make([]DecisionEvent, len(log))
+	for i, d := range log {
+		events[i] = DecisionEvent{Liked: d.liked, SuperLike: d.superLike, Unix: unixSeconds(d.updatedAt)}
+	}
+	return events, nil
+}
+
+// ListLikeChanges returns up to limit changes to the recipient's
+// likers after the given sequence number, oldest first, leaving out
+// actors the recipient has a block with and hidden actors.
+func (s *MemoryStore) ListLikeChanges(ctx context.Context, recipientID string, after int64, limit int) ([]LikeChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, errors.New("limit must be positive")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := s.likeChanges[recipientID]
+	i := sort.Search(len(all), func(i int) bool { return all[i].Seq > after })
+	changes := make([]LikeChange, 0)
+	for ; i < len(all) && len(changes) < limit; i++ {
+		if !s.blockedLocked(recipientID, all[i].ActorID) && !s.hidden[all[i].ActorID] {
+			changes = append(changes, all[i])
+		}
+	}
+	return changes, nil
+}
+
+// LatestLikeChange returns the sequence number of the recipient's most
+// recent like change, or 0 if there is none.
+func (s *MemoryStore) LatestLikeChange(ctx context.Context, recipientID string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := s.likeChanges[recipientID]
+	if len(all) == 0 {
+		return 0, nil
+	}
+	return all[len(all)-1].Seq, nil
+}
+
+// SubscribeLikedYou subscribes to changes to the recipient's likers.
+func (s *MemoryStore) SubscribeLikedYou(recipientID string) (<-chan struct{}, func()) {
+	return s.likes.subscribe(recipientID)
+}
+
+// DrainOutbox publishes up to limit of the oldest pending events and
+// removes those that were published.  The store lock is not held
+// while publishing.
+func (s *MemoryStore) DrainOutbox(ctx context.Context, limit int, publish func(context.Context, outbox.Event) error) (int, error) {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+	s.mu.RLock()
+	batch := make([]outbox.Event, min(limit, len(s.outbox)))
+	copy(batch, s.outbox)
+	s.mu.RUnlock()
+	published := 0
+	var publishErr error
+	for _, e := range batch {
+		if publishErr = publish(ctx, e); publishErr != nil {
+			break
+		}
+		published++
+	}
+	// Only drains remove events and they are serialised, so the
+	// published events are still at the front of the outbox.
+	s.mu.Lock()
+	s.outbox = s.outbox[published:]
+	s.mu.Unlock()
+	return published, publishErr
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// QueryTracer is a pgx.QueryTracer that records the duration of every
+// query in Prometheus.  Queries are labelled with the storage
+// operation that ran them, such as "ListLikedYou" or "PutDecisions",
+// or "other" for queries made outside Store, and with whether they
+// failed.  Install it on the pool's connection config before the pool
+// is created:
+//
+//	cfg.ConnConfig.Tracer = storage.NewQueryTracer(reg)
+//
+// The duration of a query that returns rows runs until the rows are
+// closed, so it includes reading them.
+type QueryTracer struct {
+	duration *prometheus.HistogramVec
+}
+
+// NewQueryTracer constructs a QueryTracer and registers its metrics
+// with reg unless it is nil.
+func NewQueryTracer(reg prometheus.Registerer) *QueryTracer {
+	t := &QueryTracer{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "explore_db_query_duration_seconds",
+			Help:    "Time taken by database queries, by storage operation and result (ok or error).",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 15),
+		}, []string{"operation", "result"}),
+	}
+	if reg != nil {
+		reg.MustRegister(t.duration)
+	}
+	return t
+}
+
+type operationKey struct{}
+
+// withOperation labels ctx with the name of the storage operation
+// whose queries it runs.  Every Store method labels its context on
+// entry.  An existing label is kept, so queries run on behalf of an
+// outer operation are attributed to it.
+func withOperation(ctx context.Context, name string) context.Context {
+	if _, ok := ctx.Value(operationKey{}).(string); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, operationKey{}, name)
+}
+
+// queryTrace is what TraceQueryStart hands to TraceQueryEnd through
+// the context.
+type queryTrace struct {
+	operation string
+	start     time.Time
+}
+
+type queryTraceKey struct{}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	operation, ok := ctx.Value(operationKey{}).(string)
+	if !ok {
+		operation = "other"
+	}
+	return context.WithValue(ctx, queryTraceKey{}, queryTrace{operation: operation, start: time.Now()})
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, ok := ctx.Value(queryTraceKey{}).(queryTrace)
+	if !ok {
+		return
+	}
+	result := "ok"
+	if data.Err != nil {
+		result = "error"
+	}
+	t.duration.WithLabelValues(trace.operation, result).Observe(time.Since(trace.start).Seconds())
+}
+
+// poolCollector exports the statistics of a pgxpool.Pool.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquired, idle, constructing, total, max  *prometheus.Desc
+	acquires, emptyAcquires, canceledAcquires *prometheus.Desc
+	acquireWait                               *prometheus.Desc
+}
+
+// NewPoolCollector returns a Prometheus collector that reports the
+// connection counts and acquire statistics of pool each time it is
+// scraped.
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("explore_db_pool_"+name, help, nil, nil)
+	}
+	return &poolCollector{
+		pool:             pool,
+		acquired:         desc("acquired_connections", "Connections currently acquired from the pool."),
+		idle:             desc("idle_connections", "Idle connections in the pool."),
+		constructing:     desc("constructing_connections", "Connections being established."),
+		total:            desc("connections", "Connections in the pool, acquired, idle or being established."),
+		max:              desc("max_connections", "Maximum size of the pool."),
+		acquires:         desc("acquires_total", "Successful acquires from the pool."),
+		emptyAcquires:    desc("empty_acquires_total", "Successful acquires that had to wait for a connection."),
+		canceledAcquires: desc("canceled_acquires_total", "Acquires cancelled by their context."),
+		acquireWait:      desc("acquire_duration_seconds_total", "Total time spent in successful acquires."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{c.acquired, c.idle, c.constructing, c.total, c.max, c.acquires, c.emptyAcquires, c.canceledAcquires, c.acquireWait} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	st := c.pool.Stat()
+	gauge := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v)
+	}
+	counter := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v)
+	}
+	gauge(c.acquired, float64(st.AcquiredConns()))
+	gauge(c.idle, float64(st.IdleConns()))
+	gauge(c.constructing, float64(st.ConstructingConns()))
+	gauge(c.total, float64(st.TotalConns()))
+	gauge(c.max, float64(st.MaxConns()))
+	counter(c.acquires, float64(st.AcquireCount()))
+	counter(c.emptyAcquires, float64(st.EmptyAcquireCount()))
+	counter(c.canceledAcquires, float64(st.CanceledAcquireCount()))
+	counter(c.acquireWait, st.AcquireDuration().Seconds())
+}
@@ -0,0 +1,14 @@
+-- Baseline schema.  IF NOT EXISTS lets databases created before the
+-- migration framework adopt this version without changes.
+CREATE TABLE IF NOT EXISTS decisions (
+    actor_user_id     TEXT    NOT NULL,
+    recipient_user_id TEXT    NOT NULL,
+    liked_recipient   BOOLEAN NOT NULL,
+    updated_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (actor_user_id, recipient_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_decisions_recipient ON decisions (recipient_user_id);
+CREATE INDEX IF NOT EXISTS idx_decisions_updated_at ON decisions (updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_decisions_actor_recipient_liked ON decisions (recipient_user_id, liked_recipient);
+CREATE INDEX IF NOT EXISTS idx_decisions_recipient_liked_keyset ON decisions (recipient_user_id, updated_at DESC, actor_user_id DESC) WHERE liked_recipient;
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_decisions_recipient ON decisions (recipient_user_id);
+CREATE INDEX IF NOT EXISTS idx_decisions_updated_at ON decisions (updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_decisions_actor_recipient_liked ON decisions (recipient_user_id, liked_recipient);
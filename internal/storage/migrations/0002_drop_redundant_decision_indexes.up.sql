@@ -0,0 +1,6 @@
+-- The liked-you listings and counts are served by the partial keyset
+-- index and the mutual-like lookups by the primary key, so these
+-- indexes only slow down writes.
+DROP INDEX IF EXISTS idx_decisions_recipient;
+DROP INDEX IF EXISTS idx_decisions_updated_at;
+DROP INDEX IF EXISTS idx_decisions_actor_recipient_liked;
@@ -0,0 +1,18 @@
+-- Every match is stored twice, once under each user, so that listing
+-- a user's matches is a single keyset scan.
+CREATE TABLE matches (
+    user_id         TEXT NOT NULL,
+    matched_user_id TEXT NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, matched_user_id)
+);
+
+CREATE INDEX idx_matches_user_keyset ON matches (user_id, created_at DESC, matched_user_id DESC);
+
+-- Backfill the mutual likes recorded so far.  The join is symmetric,
+-- so both rows of each match are produced.
+INSERT INTO matches (user_id, matched_user_id, created_at)
+SELECT a.actor_user_id, a.recipient_user_id, GREATEST(a.updated_at, b.updated_at)
+FROM decisions a
+JOIN decisions b ON b.actor_user_id = a.recipient_user_id AND b.recipient_user_id = a.actor_user_id
+WHERE a.liked_recipient AND b.liked_recipient;
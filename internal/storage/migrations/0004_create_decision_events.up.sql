@@ -0,0 +1,19 @@
+-- Append-only log of every decision written through PutDecision.
+-- decisions only holds the latest state of each pair; this table
+-- keeps the full sequence of likes and passes.
+CREATE TABLE decision_events (
+    id                BIGSERIAL PRIMARY KEY,
+    actor_user_id     TEXT    NOT NULL,
+    recipient_user_id TEXT    NOT NULL,
+    liked_recipient   BOOLEAN NOT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_decision_events_pair ON decision_events (actor_user_id, recipient_user_id, id);
+
+-- Seed the log with the current state of every pair so that existing
+-- decisions have a starting point.
+INSERT INTO decision_events (actor_user_id, recipient_user_id, liked_recipient, created_at)
+SELECT actor_user_id, recipient_user_id, liked_recipient, updated_at
+FROM decisions
+ORDER BY updated_at, actor_user_id, recipient_user_id;
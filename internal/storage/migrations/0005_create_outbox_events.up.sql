@@ -0,0 +1,8 @@
+-- Transactional outbox: events are inserted in the same transaction
+-- as the decision that caused them and deleted once relayed.
+CREATE TABLE outbox_events (
+    id         BIGSERIAL PRIMARY KEY,
+    event_type TEXT  NOT NULL,
+    payload    JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
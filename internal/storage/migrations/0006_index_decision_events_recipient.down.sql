@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_decision_events_recipient;
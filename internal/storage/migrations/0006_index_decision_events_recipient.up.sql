@@ -0,0 +1,4 @@
+-- WatchLikedYou replays a recipient's decision events after a resume
+-- position, so the events need to be reachable by recipient in id
+-- order.
+CREATE INDEX idx_decision_events_recipient ON decision_events (recipient_user_id, id);
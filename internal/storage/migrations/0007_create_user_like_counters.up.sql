@@ -0,0 +1,23 @@
+-- Per-user counts maintained by PutDecision so that counting likes does
+-- not scan every decision of popular users.  likes_received counts the
+-- actors who like the user, new_likes those of them the user has not
+-- liked back and matches the user's rows in matches.
+CREATE TABLE user_like_counters (
+    user_id        TEXT PRIMARY KEY,
+    likes_received BIGINT NOT NULL DEFAULT 0,
+    new_likes      BIGINT NOT NULL DEFAULT 0,
+    matches        BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO user_like_counters (user_id, likes_received, new_likes, matches)
+SELECT user_id, SUM(likes), SUM(new_likes), SUM(matches)
+FROM (
+    SELECT d.recipient_user_id AS user_id, 1 AS likes, (r.actor_user_id IS NULL)::int AS new_likes, 0 AS matches
+    FROM decisions d
+    LEFT JOIN decisions r ON r.actor_user_id = d.recipient_user_id AND r.recipient_user_id = d.actor_user_id AND r.liked_recipient = TRUE
+    WHERE d.liked_recipient = TRUE
+    UNION ALL
+    SELECT user_id, 0, 0, 1
+    FROM matches
+) c
+GROUP BY user_id;
@@ -0,0 +1,15 @@
+-- Results of PutDecision calls made with an idempotency key, so that a
+-- retry returns the original result instead of recording the decision
+-- again.  Keys are scoped to the actor and kept until expires_at.
+CREATE TABLE idempotency_keys (
+    actor_user_id     TEXT    NOT NULL,
+    idempotency_key   TEXT    NOT NULL,
+    recipient_user_id TEXT    NOT NULL,
+    liked_recipient   BOOLEAN NOT NULL,
+    mutual_likes      BOOLEAN NOT NULL,
+    expires_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (actor_user_id, idempotency_key)
+);
+
+-- Lets the garbage collector find expired keys without a full scan.
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
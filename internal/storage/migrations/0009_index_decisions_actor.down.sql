@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_decisions_actor_keyset;
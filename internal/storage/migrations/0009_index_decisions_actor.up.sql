@@ -0,0 +1,5 @@
+-- ListMyDecisions pages through an actor's decisions by updated_at.
+-- The primary key leads with the actor but is ordered by recipient,
+-- so the listing needs its own keyset index.  liked_recipient is
+-- included so that filtering likes or passes does not visit the heap.
+CREATE INDEX idx_decisions_actor_keyset ON decisions (actor_user_id, updated_at DESC, recipient_user_id DESC) INCLUDE (liked_recipient);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_decision_events_actor_rewindable;
+ALTER TABLE decision_events
+    DROP COLUMN IF EXISTS rewind,
+    DROP COLUMN IF EXISTS rewound;
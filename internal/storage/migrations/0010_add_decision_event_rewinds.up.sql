@@ -0,0 +1,10 @@
+-- RewindDecision undoes an actor's latest decision.  The undone event
+-- is kept but marked rewound, and a rewind event recording the
+-- restored state is appended so that watchers see the change.  Both
+-- are left out of the decision history.
+ALTER TABLE decision_events
+    ADD COLUMN rewind  BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN rewound BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Finds an actor's latest decision that can still be rewound.
+CREATE INDEX idx_decision_events_actor_rewindable ON decision_events (actor_user_id, id) WHERE NOT rewind AND NOT rewound;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_decision_events_actor_super_likes;
+DROP INDEX IF EXISTS idx_decisions_actor_keyset;
+CREATE INDEX idx_decisions_actor_keyset ON decisions (actor_user_id, updated_at DESC, recipient_user_id DESC) INCLUDE (liked_recipient);
+DROP INDEX IF EXISTS idx_decisions_recipient_liked_keyset;
+CREATE INDEX idx_decisions_recipient_liked_keyset ON decisions (recipient_user_id, updated_at DESC, actor_user_id DESC) WHERE liked_recipient;
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS super_like;
+ALTER TABLE decision_events
+    DROP COLUMN IF EXISTS super_like;
+ALTER TABLE decisions
+    DROP CONSTRAINT IF EXISTS decisions_super_like_liked,
+    DROP COLUMN IF EXISTS super_like;
@@ -0,0 +1,22 @@
+-- A super like is a like that the recipient sees flagged.  It is
+-- stored as a flag on the like so that every query treating likes
+-- alike keeps working, and can only be set together with
+-- liked_recipient.
+ALTER TABLE decisions
+    ADD COLUMN super_like BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT decisions_super_like_liked CHECK (liked_recipient OR NOT super_like);
+ALTER TABLE decision_events
+    ADD COLUMN super_like BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE idempotency_keys
+    ADD COLUMN super_like BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The liker and own-decision listings return the flag, so the keyset
+-- indexes carry it to keep those listings off the heap.
+DROP INDEX IF EXISTS idx_decisions_recipient_liked_keyset;
+CREATE INDEX idx_decisions_recipient_liked_keyset ON decisions (recipient_user_id, updated_at DESC, actor_user_id DESC) INCLUDE (super_like) WHERE liked_recipient;
+DROP INDEX IF EXISTS idx_decisions_actor_keyset;
+CREATE INDEX idx_decisions_actor_keyset ON decisions (actor_user_id, updated_at DESC, recipient_user_id DESC) INCLUDE (liked_recipient, super_like);
+
+-- The daily quota counts an actor's recent super likes that have not
+-- been rewound.
+CREATE INDEX idx_decision_events_actor_super_likes ON decision_events (actor_user_id, created_at) WHERE super_like AND NOT rewind AND NOT rewound;
@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS blocked_pairs;
+DROP TABLE IF EXISTS blocks;
@@ -0,0 +1,25 @@
+-- Users a user has blocked.  A block hides the two users from each
+-- other whichever of them created it: their likes are not listed or
+-- counted and they cannot match.
+CREATE TABLE blocks (
+    blocker_user_id TEXT NOT NULL,
+    blocked_user_id TEXT NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_user_id, blocked_user_id)
+);
+
+-- Finds the blocks against a user, for the reverse direction of
+-- blocked_pairs.
+CREATE INDEX idx_blocks_blocked ON blocks (blocked_user_id, blocker_user_id);
+
+-- ListBlocked pages through a user's blocks, newest first.
+CREATE INDEX idx_blocks_blocker_keyset ON blocks (blocker_user_id, created_at DESC, blocked_user_id DESC);
+
+-- Every block in both directions, so that a query can exclude a pair
+-- with one lookup on user_id and other_user_id.  Conditions on the
+-- view are pushed into both branches, which are served by the primary
+-- key and idx_blocks_blocked.
+CREATE VIEW blocked_pairs (user_id, other_user_id) AS
+SELECT blocker_user_id, blocked_user_id FROM blocks
+UNION ALL
+SELECT blocked_user_id, blocker_user_id FROM blocks;
@@ -0,0 +1,16 @@
+-- One row per DeleteUserData call.  The row is inserted when the
+-- erasure starts and completed_at is set, together with the number of
+-- rows deleted, once every row about the user is gone, so an erasure
+-- that was interrupted shows up with completed_at unset.
+CREATE TABLE erasure_log (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         TEXT   NOT NULL,
+    started_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at    TIMESTAMP WITH TIME ZONE,
+    decisions       BIGINT NOT NULL DEFAULT 0,
+    decision_events BIGINT NOT NULL DEFAULT 0,
+    matches         BIGINT NOT NULL DEFAULT 0,
+    blocks          BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_erasure_log_user ON erasure_log (user_id, id);
@@ -0,0 +1,14 @@
+-- Account status of users, written by SetUserVisibility.  The likes of
+-- a hidden user, for example one who paused or deactivated their
+-- account, are left out of other users' liker listings and counts
+-- until the user is visible again; their decisions are kept.  Users
+-- without a row are visible.
+--
+-- An import job may write this table directly, but only
+-- SetUserVisibility keeps user_like_counters in step; run
+-- "explore-service repair-counters" after a direct import.
+CREATE TABLE user_status (
+    user_id    TEXT    PRIMARY KEY,
+    hidden     BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
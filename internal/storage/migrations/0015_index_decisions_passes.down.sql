@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_decisions_passes_updated_at;
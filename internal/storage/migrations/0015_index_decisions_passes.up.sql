@@ -0,0 +1,4 @@
+-- The pass sweeper deletes passes last updated before the pass TTL,
+-- oldest first, in small batches.  This index lets each batch find
+-- them without scanning the likes.
+CREATE INDEX idx_decisions_passes_updated_at ON decisions (updated_at) WHERE NOT liked_recipient;
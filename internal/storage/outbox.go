@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"explore_service/internal/outbox"
+)
+
+var (
+	_ outbox.Source = (*Store)(nil)
+	_ outbox.Source = (*MemoryStore)(nil)
+)
+
+// decisionEvents returns the outbox events caused by a decision.  A
+// like only produces LikeReceived when the actor did not already like
+// the recipient, so repeated likes do not notify twice.  Upgrading a
+// like to a super like produces it again, flagged as a super like.
+// Likes between blocked users, and likes by hidden actors, produce
+// nothing.
+func decisionEvents(actorID, recipientID string, decision DecisionType, change decisionChange) []outbox.Event {
+	var events []outbox.Event
+	add := func(t outbox.EventType) {
+		events = append(events, outbox.Event{Type: t, ActorID: actorID, RecipientID: recipientID, OccurredAt: change.at})
+	}
+	superLike := decision == SuperLike
+	if decision.liked() && !change.blocked && !change.actorHidden && (!change.wasLiked || superLike && !change.wasSuperLike) {
+		add(outbox.LikeReceived)
+		events[len(events)-1].SuperLike = superLike
+	}
+	if change.matchCreated {
+		add(outbox.MatchCreated)
+	}
+	if change.matchRemoved {
+		add(outbox.MatchRemoved)
+	}
+	return events
+}
+
+// rewindEvents returns the outbox events caused by rewinding the
+// actor's decision on the recipient.
+func rewindEvents(actorID, recipientID string, change decisionChange) []outbox.Event {
+	events := []outbox.Event{{Type: outbox.DecisionRewound, ActorID: actorID, RecipientID: recipientID, OccurredAt: change.at}}
+	if change.matchCreated {
+		events = append(events, outbox.Event{Type: outbox.MatchCreated, ActorID: actorID, RecipientID: recipientID, OccurredAt: change.at})
+	}
+	if change.matchRemoved {
+		events = append(events, outbox.Event{Type: outbox.MatchRemoved, ActorID: actorID, RecipientID: recipientID, OccurredAt: change.at})
+	}
+	return events
+}
+
+// enqueueEvents writes events to the outbox inside tx, so they become
+// visible to the relay only if the decision commits.
+func enqueueEvents(ctx context.Context, tx pgx.Tx, events []outbox.Event) error {
+	const insert = `
+INSERT INTO outbox_events (event_type, payload)
+VALUES ($1, $2);
+    `
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, insert, string(e.Type), payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DrainOutbox publishes up to limit of the oldest outbox events and
+// deletes those that were published, all in one transaction.  Rows
+// are locked with SKIP LOCKED so that several relays can drain
+// concurrently without publishing the same event at the same time.
+// If the process dies between publishing and committing, the events
+// are published again by the next drain.
+func (s *Store) DrainOutbox(ctx context.Context, limit int, publish func(context.Context, outbox.Event) error) (int, error) {
+	ctx = withOperation(ctx, "DrainOutbox")
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	const query = `
+SELECT id, event_type, payload
+FROM outbox_events
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+    `
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+	var events []outbox.Event
+	for rows.Next() {
+		var id int64
+		var eventType string
+		var payload []byte
+		if err := rows.Scan(&id, &eventType, &payload); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		var e outbox.Event
+		if err := json.Unmarshal(payload, &e); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("outbox event %d: %w", id, err)
+		}
+		e.ID, e.Type = id, outbox.EventType(eventType)
+		events = append(events, e)
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return 0, rows.Err()
+	}
+	published := make([]int64, 0, len(events))
+	var publishErr error
+	for _, e := range events {
+		if publishErr = publish(ctx, e); publishErr != nil {
+			break
+		}
+		published = append(published, e.ID)
+	}
+	if len(published) > 0 {
+		if _, err := tx.Exec(ctx, `DELETE FROM outbox_events WHERE id = ANY($1);`, published); err != nil {
+			return 0, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return 0, err
+		}
+	}
+	return len(published), publishErr
+}
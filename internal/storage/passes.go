@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// WithPassTTL makes passes expire ttl after they were last updated.
+// An expired pass no longer keeps the recipient out of FilterUndecided
+// results, so the recipient can be shown to the actor again, and is
+// removed by DeleteExpiredPasses.  RewindDecision treats it as no
+// decision even before it is swept, so a rewind never undoes or
+// restores a pass the sweeper may have deleted.  A ttl of zero, the
+// default, keeps passes forever.
+func WithPassTTL(ttl time.Duration) Option {
+	return func(s *Store) { s.passTTL = ttl }
+}
+
+// WithMemoryPassTTL is WithPassTTL for a MemoryStore.
+func WithMemoryPassTTL(ttl time.Duration) MemoryOption {
+	return func(s *MemoryStore) { s.passTTL = ttl }
+}
+
+// DeleteExpiredPasses deletes passes older than the pass TTL from the
+// actors' current decisions and returns how many were deleted.  Their
+// events stay in the decision history, which serves as the archive.
+// Passes are deleted batchSize at a time, oldest first, each batch in
+// its own short transaction, so sweeping a large backlog never holds
+// locks for long.  Passes being overwritten by a concurrent decision
+// are skipped.  Nothing is deleted if passes do not expire.
+//
+// A pass and no decision at all have the same effect on matches and
+// like counters, so removing one changes neither.
+func (s *Store) DeleteExpiredPasses(ctx context.Context, batchSize int) (int64, error) {
+	ctx = withOperation(ctx, "DeleteExpiredPasses")
+	if s.passTTL <= 0 {
+		return 0, nil
+	}
+	const query = `
+DELETE FROM decisions
+WHERE (actor_user_id, recipient_user_id) IN (
+    SELECT actor_user_id, recipient_user_id
+    FROM decisions
+    WHERE NOT liked_recipient AND updated_at <= NOW() - make_interval(secs => $1)
+    ORDER BY updated_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+);
+    `
+	var total int64
+	for {
+		tag, err := s.pool.Exec(ctx, query, s.passTTL.Seconds(), batchSize)
+		if err != nil {
+			return total, err
+		}
+		n := tag.RowsAffected()
+		total += n
+		if n == 0 || n < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
+
+// passExpired reports whether d is a pass older than the pass TTL at
+// now.
+func (s *MemoryStore) passExpired(d memoryDecision, now time.Time) bool {
+	return s.passTTL > 0 && !d.liked && !d.updatedAt.After(now.Add(-s.passTTL))
+}
+
+// DeleteExpiredPasses deletes passes older than the pass TTL and
+// returns how many were deleted; see Store.DeleteExpiredPasses.
+// batchSize is ignored as every decision is swept under one lock.
+func (s *MemoryStore) DeleteExpiredPasses(ctx context.Context, _ int) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	var n int64
+	for _, byActor := range s.received {
+		for actorID, d := range byActor {
+			if s.passExpired(d, now) {
+				delete(byActor, actorID)
+				n++
+			}
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrNothingToRewind is returned by RewindDecision when the actor has
+// no decision made within the rewind window left to undo.
+var ErrNothingToRewind = errors.New("no decision to rewind")
+
+// errRewindRaced reports that the actor's latest decision changed
+// between finding it and locking its pair.
+var errRewindRaced = errors.New("latest decision changed while rewinding")
+
+// maxRewindAttempts bounds how often RewindDecision starts over after
+// racing with a concurrent decision by the same actor.
+const maxRewindAttempts = 3
+
+// RewindDecision undoes the actor's latest decision made within window.
+// A pass older than the pass TTL counts as no decision, whether or not
+// it has been swept yet: it is neither undone nor restored.
+// The undone event is marked rewound and a rewind event holding the
+// restored state is appended to the log, so watchers of the recipient
+// see the change.  Match, counter and outbox updates are made in the
+// same transaction as for PutDecision.
+func (s *Store) RewindDecision(ctx context.Context, actorID string, window time.Duration) (Rewind, error) {
+	ctx = withOperation(ctx, "RewindDecision")
+	for attempt := 1; ; attempt++ {
+		r, err := s.rewindDecision(ctx, actorID, window)
+		if errors.Is(err, errRewindRaced) && attempt < maxRewindAttempts {
+			continue
+		}
+		return r, err
+	}
+}
+
+// rewindDecision makes one attempt at RewindDecision.
+func (s *Store) rewindDecision(ctx context.Context, actorID string, window time.Duration) (Rewind, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return Rewind{}, err
+	}
+	defer func() {
+		// If the transaction is still open, roll it back.
+		_ = tx.Rollback(ctx)
+	}()
+	// The pair to lock is only known once the latest decision has been
+	// read, so it is read again under the lock to make sure no other
+	// decision by the actor was made in between.
+	id, rewind, err := latestRewindable(ctx, tx, actorID, window, s.passTTL)
+	if err != nil {
+		return Rewind{}, err
+	}
+	if err := lockDecisions(ctx, tx, actorID, []string{rewind.RecipientID}); err != nil {
+		return Rewind{}, err
+	}
+	lockedID, _, err := latestRewindable(ctx, tx, actorID, window, s.passTTL)
+	if err != nil {
+		return Rewind{}, err
+	}
+	if lockedID != id {
+		return Rewind{}, errRewindRaced
+	}
+	if _, err := tx.Exec(ctx, `UPDATE decision_events SET rewound = TRUE WHERE id = $1;`, id); err != nil {
+		return Rewind{}, err
+	}
+	// Restore the latest decision on the recipient that has not been
+	// rewound, with its original time, or delete the decision if there
+	// is none or it is an expired pass.
+	const previous = `
+SELECT liked_recipient, super_like, created_at,
+       liked_recipient OR $3::float8 = 0 OR created_at > NOW() - make_interval(secs => $3::float8)
+FROM decision_events
+WHERE actor_user_id = $1 AND recipient_user_id = $2 AND NOT rewind AND NOT rewound
+ORDER BY id DESC
+LIMIT 1;
+    `
+	var restoredAt time.Time
+	var live bool
+	restored := StoredDecision{RecipientID: rewind.RecipientID}
+	err = tx.QueryRow(ctx, previous, actorID, rewind.RecipientID, s.passTTL.Seconds()).Scan(&restored.Liked, &restored.SuperLike, &restoredAt, &live)
+	if err == nil && !live {
+		restored, err = StoredDecision{RecipientID: rewind.RecipientID}, pgx.ErrNoRows
+	}
+	switch {
+	case err == nil:
+		const restore = `
+UPDATE decisions
+SET liked_recipient = $3, super_like = $4, updated_at = $5
+WHERE actor_user_id = $1 AND recipient_user_id = $2;
+        `
+		if _, err := tx.Exec(ctx, restore, actorID, rewind.RecipientID, restored.Liked, restored.SuperLike, restoredAt); err != nil {
+			return Rewind{}, err
+		}
+		restored.Unix = unixSeconds(restoredAt)
+		rewind.Restored = &restored
+	case errors.Is(err, pgx.ErrNoRows):
+		const remove = `
+DELETE FROM decisions
+WHERE actor_user_id = $1 AND recipient_user_id = $2;
+        `
+		if _, err := tx.Exec(ctx, remove, actorID, rewind.RecipientID); err != nil {
+			return Rewind{}, err
+		}
+	default:
+		return Rewind{}, err
+	}
+	change := decisionChange{wasLiked: rewind.Liked}
+	var backAt time.Time
+	const back = `
+SELECT liked_recipient, updated_at
+FROM decisions
+WHERE actor_user_id = $1 AND recipient_user_id = $2;
+    `
+	if err := tx.QueryRow(ctx, back, rewind.RecipientID, actorID).Scan(&change.likedBack, &backAt); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return Rewind{}, err
+	}
+	if change.blocked, change.actorHidden, change.recipientHidden, err = pairVisibility(ctx, tx, actorID, rewind.RecipientID); err != nil {
+		return Rewind{}, err
+	}
+	liked := restored.Liked
+	change.mutual = liked && change.likedBack && !change.blocked
+	// A restored mutual like gets its match back, dated when the like
+	// became mutual; otherwise any match with the recipient is gone.
+	if change.mutual {
+		const insertMatch = `
+INSERT INTO matches (user_id, matched_user_id, created_at)
+VALUES ($1, $2, $3), ($2, $1, $3)
+ON CONFLICT (user_id, matched_user_id) DO NOTHING;
+        `
+		matchedAt := restoredAt
+		if backAt.After(matchedAt) {
+			matchedAt = backAt
+		}
+		tag, err := tx.Exec(ctx, insertMatch, actorID, rewind.RecipientID, matchedAt)
+		if err != nil {
+			return Rewind{}, err
+		}
+		change.matchCreated = tag.RowsAffected() > 0
+	} else {
+		const deleteMatch = `
+DELETE FROM matches
+WHERE (user_id = $1 AND matched_user_id = $2) OR (user_id = $2 AND matched_user_id = $1);
+        `
+		tag, err := tx.Exec(ctx, deleteMatch, actorID, rewind.RecipientID)
+		if err != nil {
+			return Rewind{}, err
+		}
+		change.matchRemoved = tag.RowsAffected() > 0
+	}
+	const logRewind = `
+INSERT INTO decision_events (actor_user_id, recipient_user_id, liked_recipient, super_like, created_at, rewind)
+VALUES ($1, $2, $3, $4, NOW(), TRUE)
+RETURNING created_at;
+    `
+	if err := tx.QueryRow(ctx, logRewind, actorID, rewind.RecipientID, liked, restored.SuperLike).Scan(&change.at); err != nil {
+		return Rewind{}, err
+	}
+	if err := updateCounters(ctx, tx, counterDeltas(actorID, rewind.RecipientID, liked, change)); err != nil {
+		return Rewind{}, err
+	}
+	if err := enqueueEvents(ctx, tx, rewindEvents(actorID, rewind.RecipientID, change)); err != nil {
+		return Rewind{}, err
+	}
+	if liked || change.wasLiked {
+		if err := notifyLikedYou(ctx, tx, rewind.RecipientID); err != nil {
+			return Rewind{}, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return Rewind{}, err
+	}
+	return rewind, nil
+}
+
+// latestRewindable returns the id of the actor's latest decision event
+// that has not been rewound, with the recipient and outcome filled in
+// the returned Rewind.  It fails with ErrNothingToRewind if there is
+// none, it is older than window or it is a pass older than passTTL.
+func latestRewindable(ctx context.Context, tx pgx.Tx, actorID string, window, passTTL time.Duration) (int64, Rewind, error) {
+	const query = `
+SELECT id, recipient_user_id, liked_recipient,
+       created_at >= NOW() - make_interval(secs => $2)
+       AND (liked_recipient OR $3::float8 = 0 OR created_at > NOW() - make_interval(secs => $3::float8))
+FROM decision_events
+WHERE actor_user_id = $1 AND NOT rewind AND NOT rewound
+ORDER BY id DESC
+LIMIT 1;
+    `
+	var id int64
+	var r Rewind
+	var recent bool
+	err := tx.QueryRow(ctx, query, actorID, window.Seconds(), passTTL.Seconds()).Scan(&id, &r.RecipientID, &r.Liked, &recent)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !recent) {
+		return 0, Rewind{}, ErrNothingToRewind
+	}
+	return id, r, err
+}
@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DecisionStore is the storage contract used by the ExploreService.
+// Store implements it on top of PostgreSQL and MemoryStore keeps
+// everything in process memory.  Both implementations must agree on
+// ordering and mutual-like semantics so that either can back the
+// service.
+type DecisionStore interface {
+	// PutDecision stores or updates a decision and reports whether
+	// the like is now mutual.  A super like beyond the actor's daily
+	// quota fails with ErrSuperLikeQuotaExceeded.
+	PutDecision(ctx context.Context, actorID, recipientID string, decision DecisionType) (bool, error)
+	// PutDecisionOnce is PutDecision for a request identified by an
+	// idempotency key.  The result of the first call with a key is
+	// remembered for ttl, and repeating the call with the same key and
+	// decision returns it without storing the decision again.  Reusing
+	// a remembered key for a different decision fails with
+	// ErrIdempotencyKeyReused.
+	PutDecisionOnce(ctx context.Context, key string, ttl time.Duration, actorID, recipientID string, decision DecisionType) (bool, error)
+	// PutDecisions stores the actor's decisions in order in a single
+	// transaction and returns one result per decision.  A decision
+	// that fails is skipped and reported in its result without
+	// affecting the others.  If the returned error is non-nil nothing
+	// was stored.
+	PutDecisions(ctx context.Context, actorID string, decisions []Decision) ([]DecisionResult, error)
+	// ListLikedYou returns the actors who like the recipient in the
+	// given order, starting after the given cursor.  The returned
+	// cursor is nil on the last page.  A cursor from a listing in a
+	// different order is rejected with ErrInvalidCursor.
+	// Only likes last updated within window are listed.  Like every
+	// listing and count of likes, it leaves out actors who blocked the
+	// recipient or were blocked by them, and actors who are hidden.
+	ListLikedYou(ctx context.Context, recipientID string, window TimeRange, after *Cursor, limit int, order Order) ([]Liker, *Cursor, error)
+	// ListNewLikedYou returns the actors who like the recipient and
+	// have not been liked back, paginated like ListLikedYou.
+	ListNewLikedYou(ctx context.Context, recipientID string, window TimeRange, after *Cursor, limit int, order Order) ([]Liker, *Cursor, error)
+	// CountLikedYou returns the number of actors who like the
+	// recipient, counting only likes last updated within window.
+	CountLikedYou(ctx context.Context, recipientID string, window TimeRange) (uint64, error)
+	// CountNewLikedYou returns the number of actors ListNewLikedYou
+	// would list for the recipient and window.
+	CountNewLikedYou(ctx context.Context, recipientID string, window TimeRange) (uint64, error)
+	// GetLikeSummary returns the user's like and match counts.
+	GetLikeSummary(ctx context.Context, userID string) (LikeSummary, error)
+	// ListMatches returns the users the given user has a mutual like
+	// with, most recent match first, paginated like ListLikedYou.
+	ListMatches(ctx context.Context, userID string, after *Cursor, limit int) ([]Match, *Cursor, error)
+	// RewindDecision undoes the actor's latest decision if it was made
+	// within window: the previous decision on the same recipient is
+	// restored, or the decision is deleted if there was none, and a
+	// match that no longer holds is removed.  Successive calls undo
+	// earlier decisions.  It fails with ErrNothingToRewind if there is
+	// no decision to undo.
+	RewindDecision(ctx context.Context, actorID string, window time.Duration) (Rewind, error)
+	// GetDecisionHistory returns every decision the actor has
+	// recorded for the recipient, oldest first, leaving out rewound
+	// decisions.
+	GetDecisionHistory(ctx context.Context, actorID, recipientID string) ([]DecisionEvent, error)
+	// GetDecision returns the actor's current decision on the
+	// recipient.  ok is false if the actor has not decided.
+	GetDecision(ctx context.Context, actorID, recipientID string) (d StoredDecision, ok bool, err error)
+	// ListMyDecisions returns the actor's current decisions that match
+	// filter, most recently updated first, paginated like ListMatches.
+	ListMyDecisions(ctx context.Context, actorID string, filter DecisionFilter, after *Cursor, limit int) ([]StoredDecision, *Cursor, error)
+	// FilterUndecided returns the candidates the actor has not decided
+	// on and has no block with, in the order given and without
+	// duplicates.  Passes older than the pass TTL count as undecided.
+	FilterUndecided(ctx context.Context, actorID string, candidateIDs []string) ([]string, error)
+	// BlockUser blocks blockedID for the blocker.  Until the block is
+	// lifted the two users are hidden from each other's likes and
+	// cannot match; a match between them is removed.  Blocking a user
+	// again has no effect.
+	BlockUser(ctx context.Context, blockerID, blockedID string) error
+	// UnblockUser lifts the blocker's block of blockedID.  Likes
+	// between the two become visible again, and a match is created if
+	// they like each other, unless blockedID also blocked the blocker.
+	// Lifting a block that does not exist has no effect.
+	UnblockUser(ctx context.Context, blockerID, blockedID string) error
+	// ListBlocked returns the users the blocker has blocked, most
+	// recent block first, paginated like ListMatches.
+	ListBlocked(ctx context.Context, blockerID string, after *Cursor, limit int) ([]BlockedUser, *Cursor, error)
+	// SetUserVisibility hides or shows the user.  A hidden user's
+	// likes are left out of other users' listings, counts and like
+	// changes until the user is shown again; their decisions and
+	// matches are kept.  Setting the current visibility has no effect.
+	SetUserVisibility(ctx context.Context, userID string, hidden bool) error
+	// DeleteUserData deletes everything stored about the user, as
+	// actor and as recipient, and records the erasure in the erasure
+	// log.  Other users' counts change as if the user had withdrawn
+	// their likes.  Rows are deleted in batches of batchSize.
+	DeleteUserData(ctx context.Context, userID string, batchSize int) (Erasure, error)
+	// ExportUserData passes the user's like summary, current
+	// decisions, decision history, matches and blocks to yield in
+	// batches of at most batchSize entries, stopping at the first error
+	// yield returns.
+	ExportUserData(ctx context.Context, userID string, batchSize int, yield func(UserDataExport) error) error
+	// ListLikeChanges returns up to limit changes to the recipient's
+	// likers with a sequence number greater than after, oldest first.
+	ListLikeChanges(ctx context.Context, recipientID string, after int64, limit int) ([]LikeChange, error)
+	// LatestLikeChange returns a sequence number that is at least that
+	// of the recipient's most recent like change, so ListLikeChanges
+	// after it only returns later changes.
+	LatestLikeChange(ctx context.Context, recipientID string) (int64, error)
+	// SubscribeLikedYou returns a channel that receives a value
+	// whenever the recipient's likers may have changed, and a function
+	// that ends the subscription.  Notifications are coalesced: the
+	// channel holds at most one pending value.
+	SubscribeLikedYou(recipientID string) (<-chan struct{}, func())
+}
+
+var (
+	_ DecisionStore = (*Store)(nil)
+	_ DecisionStore = (*MemoryStore)(nil)
+)
+
+// TimeRange restricts a listing or count to decisions made at or after
+// Since and strictly before Until.  A zero bound leaves that side
+// unbounded, so the zero TimeRange matches everything.
+type TimeRange struct {
+	Since, Until time.Time
+}
+
+// contains reports whether t lies within the range.
+func (r TimeRange) contains(t time.Time) bool {
+	return (r.Since.IsZero() || !t.Before(r.Since)) && (r.Until.IsZero() || t.Before(r.Until))
+}
+
+// bounds returns the range as query parameters, using -infinity and
+// +infinity for missing bounds so that one query serves every range.
+func (r TimeRange) bounds() (since, until pgtype.Timestamptz) {
+	since = pgtype.Timestamptz{Time: r.Since, InfinityModifier: pgtype.NegativeInfinity, Valid: true}
+	if !r.Since.IsZero() {
+		since.InfinityModifier = pgtype.Finite
+	}
+	until = pgtype.Timestamptz{Time: r.Until, InfinityModifier: pgtype.Infinity, Valid: true}
+	if !r.Until.IsZero() {
+		until.InfinityModifier = pgtype.Finite
+	}
+	return since, until
+}
+
+// DecisionType is the kind of decision an actor makes on a recipient.
+type DecisionType int
+
+const (
+	// Pass declines the recipient and withdraws an earlier like.
+	Pass DecisionType = iota
+	// Like likes the recipient.
+	Like
+	// SuperLike likes the recipient and flags the like in the
+	// recipient's listings.  Super likes are limited by a daily quota.
+	SuperLike
+)
+
+// liked reports whether the decision likes the recipient.
+func (t DecisionType) liked() bool {
+	return t != Pass
+}
+
+// Decision is one of the decisions passed to PutDecisions.
+type Decision struct {
+	RecipientID string
+	Type        DecisionType
+}
+
+// DecisionResult is the outcome of one decision passed to
+// PutDecisions.  Err is nil if the decision was stored, in which case
+// Mutual reports whether the like is now mutual.
+type DecisionResult struct {
+	Mutual bool
+	Err    error
+}
+
+// StoredDecision is an actor's current decision on RecipientID.
+// SuperLike is only set together with Liked.  Unix holds the seconds
+// since the Unix epoch when the decision was last updated.
+type StoredDecision struct {
+	RecipientID string
+	Liked       bool
+	SuperLike   bool
+	Unix        uint64
+}
+
+// Rewind describes a decision undone by RewindDecision.  Liked is the
+// undone decision on RecipientID and Restored the decision now in
+// effect, or nil if the actor no longer has one.
+type Rewind struct {
+	RecipientID string
+	Liked       bool
+	Restored    *StoredDecision
+}
+
+// DecisionFilter selects the decisions listed by ListMyDecisions.
+type DecisionFilter int
+
+const (
+	// AllDecisions lists likes and passes.
+	AllDecisions DecisionFilter = iota
+	// LikedDecisions lists likes only.
+	LikedDecisions
+	// PassedDecisions lists passes only.
+	PassedDecisions
+)
+
+// matches reports whether a decision with the given outcome passes the
+// filter.
+func (f DecisionFilter) matches(liked bool) bool {
+	switch f {
+	case LikedDecisions:
+		return liked
+	case PassedDecisions:
+		return !liked
+	}
+	return true
+}
+
+// liked returns the liked_recipient value the filter requires, or nil
+// if it accepts both.
+func (f DecisionFilter) liked() *bool {
+	switch f {
+	case LikedDecisions:
+		v := true
+		return &v
+	case PassedDecisions:
+		v := false
+		return &v
+	}
+	return nil
+}
+
+// Liker represents a like from an actor to a recipient.  SuperLike
+// reports whether the like is a super like.  Unix holds the seconds
+// since the Unix epoch when the decision was last updated.
+type Liker struct {
+	ActorID   string
+	SuperLike bool
+	Unix      uint64
+}
+
+// Match represents a mutual like between a user and UserID.  Unix
+// holds the seconds since the Unix epoch when the match was created.
+type Match struct {
+	UserID string
+	Unix   uint64
+}
+
+// BlockedUser is a user blocked by another.  Unix holds the seconds
+// since the Unix epoch when the block was created.
+type BlockedUser struct {
+	UserID string
+	Unix   uint64
+}
+
+// LikeSummary holds the counts shown on a user's profile: Likes is the
+// number of actors who like the user, NewLikes the number of those the
+// user has not liked back and Matches the number of matches.
+type LikeSummary struct {
+	Likes, NewLikes, Matches uint64
+}
+
+// DecisionEvent is one entry of a decision history.  Liked reports
+// whether the actor liked or passed, SuperLike whether a like was a
+// super like, and Unix holds the seconds since the Unix epoch when the
+// decision was recorded.
+type DecisionEvent struct {
+	Liked     bool
+	SuperLike bool
+	Unix      uint64
+}
+
+// LikeChange is a change to the set of actors who like a recipient.
+// Liked is true when ActorID liked the recipient and false when a pass
+// withdrew an earlier like; SuperLike is set when the like is a super
+// like.  Seq increases with every change to the recipient and serves
+// as a resume position.  Unix holds the seconds since the Unix epoch
+// when the decision was recorded.
+type LikeChange struct {
+	Seq       int64
+	ActorID   string
+	Liked     bool
+	SuperLike bool
+	Unix      uint64
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrSuperLikeQuotaExceeded is returned when an actor super likes a
+// recipient after using up their daily quota.
+var ErrSuperLikeQuotaExceeded = errors.New("daily super like quota exceeded")
+
+// DefaultSuperLikeQuota is the number of super likes an actor may make
+// per day unless configured otherwise.
+const DefaultSuperLikeQuota = 5
+
+// superLikeQuotaPeriod is the period the quota applies to.  It is a
+// rolling period rather than a calendar day, which would depend on the
+// actor's time zone.
+const superLikeQuotaPeriod = 24 * time.Hour
+
+// superLikeLockClass is the first key of the two-key advisory lock
+// that serialises an actor's super likes, so that concurrent super
+// likes of different recipients cannot both pass the quota check.
+const superLikeLockClass int32 = 2
+
+// WithSuperLikeQuota sets the number of super likes an actor may make
+// in any 24 hours.  Super likes that are rewound do not count, and
+// super liking a recipient again within the period only counts once.
+// A quota of zero disables super likes.
+func WithSuperLikeQuota(n int) Option {
+	return func(s *Store) { s.superLikeQuota = n }
+}
+
+// checkSuperLikeQuota fails with ErrSuperLikeQuotaExceeded if the
+// actor has used up the quota on recipients other than recipientID.
+// It takes the actor's super like lock, which is held until tx ends.
+func checkSuperLikeQuota(ctx context.Context, tx pgx.Tx, actorID, recipientID string, quota int) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2));`, superLikeLockClass, actorID); err != nil {
+		return err
+	}
+	const used = `
+SELECT COUNT(DISTINCT recipient_user_id)
+FROM decision_events
+WHERE actor_user_id = $1 AND recipient_user_id <> $2
+  AND super_like AND NOT rewind AND NOT rewound
+  AND created_at > NOW() - make_interval(secs => $3);
+    `
+	var n int
+	if err := tx.QueryRow(ctx, used, actorID, recipientID, superLikeQuotaPeriod.Seconds()).Scan(&n); err != nil {
+		return err
+	}
+	if n >= quota {
+		return ErrSuperLikeQuotaExceeded
+	}
+	return nil
+}
+
+// MemoryOption configures a MemoryStore.
+type MemoryOption func(*MemoryStore)
+
+// WithMemorySuperLikeQuota is WithSuperLikeQuota for a MemoryStore.
+func WithMemorySuperLikeQuota(n int) MemoryOption {
+	return func(s *MemoryStore) { s.superLikeQuota = n }
+}
+
+// checkSuperLikeQuotaLocked is checkSuperLikeQuota for a MemoryStore.
+// It walks the actor's decisions that have not been rewound from the
+// latest back to the start of the period.  The caller must hold the
+// write lock.
+func (s *MemoryStore) checkSuperLikeQuotaLocked(actorID, recipientID string) error {
+	since := time.Now().Add(-superLikeQuotaPeriod)
+	swipes := s.swipes[actorID]
+	// seen counts the entries of each pair's history already visited,
+	// which are the latest ones as swipes is walked backwards.
+	seen := make(map[memoryPair]int)
+	superLiked := make(map[string]bool)
+	for i := len(swipes) - 1; i >= 0; i-- {
+		pair := swipes[i]
+		events := s.history[pair]
+		seen[pair]++
+		d := events[len(events)-seen[pair]]
+		if !d.updatedAt.After(since) {
+			break
+		}
+		if d.superLike && pair.recipientID != recipientID {
+			superLiked[pair.recipientID] = true
+		}
+	}
+	if len(superLiked) >= s.superLikeQuota {
+		return ErrSuperLikeQuotaExceeded
+	}
+	return nil
+}
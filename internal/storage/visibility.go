@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// userStatusLockClass is the first key of the two-key advisory lock on
+// a user's visibility.  Decisions take it shared for both users, and
+// SetUserVisibility takes it exclusively.
+const userStatusLockClass int32 = 3
+
+// pairVisibility reports whether either of the two users has blocked
+// the other and whether each of them is hidden.
+func pairVisibility(ctx context.Context, tx pgx.Tx, a, b string) (blocked, aHidden, bHidden bool, err error) {
+	const query = `
+SELECT
+    EXISTS (SELECT 1 FROM blocked_pairs WHERE user_id = $1 AND other_user_id = $2),
+    EXISTS (SELECT 1 FROM user_status WHERE user_id = $1 AND hidden),
+    EXISTS (SELECT 1 FROM user_status WHERE user_id = $2 AND hidden);
+    `
+	err = tx.QueryRow(ctx, query, a, b).Scan(&blocked, &aHidden, &bHidden)
+	return blocked, aHidden, bHidden, err
+}
+
+// SetUserVisibility hides or shows the user in one transaction.  The
+// user's status lock orders the change with decisions involving the
+// user, so the user's likes are moved out of or back into the
+// recipients' counters exactly once.  Likes in blocked pairs are not
+// counted either way and are left alone.
+func (s *Store) SetUserVisibility(ctx context.Context, userID string, hidden bool) error {
+	ctx = withOperation(ctx, "SetUserVisibility")
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// If the transaction is still open, roll it back.
+		_ = tx.Rollback(ctx)
+	}()
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2));`, userStatusLockClass, userID); err != nil {
+		return err
+	}
+	var wasHidden bool
+	err = tx.QueryRow(ctx, `SELECT hidden FROM user_status WHERE user_id = $1;`, userID).Scan(&wasHidden)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if wasHidden == hidden {
+		return tx.Commit(ctx)
+	}
+	const upsert = `
+INSERT INTO user_status (user_id, hidden, updated_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (user_id) DO UPDATE SET hidden = EXCLUDED.hidden, updated_at = EXCLUDED.updated_at;
+    `
+	if _, err := tx.Exec(ctx, upsert, userID, hidden); err != nil {
+		return err
+	}
+	// The user's likes, and whether each recipient likes the user
+	// back, decide the recipients' likes and new likes.
+	const likes = `
+SELECT d.recipient_user_id, r.actor_user_id IS NOT NULL
+FROM decisions d
+LEFT JOIN decisions r ON r.actor_user_id = d.recipient_user_id AND r.recipient_user_id = d.actor_user_id AND r.liked_recipient = TRUE
+WHERE d.actor_user_id = $1 AND d.liked_recipient = TRUE
+  AND NOT EXISTS (SELECT 1 FROM blocked_pairs b WHERE b.user_id = $1 AND b.other_user_id = d.recipient_user_id);
+    `
+	rows, err := tx.Query(ctx, likes, userID)
+	if err != nil {
+		return err
+	}
+	deltas, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (counterDelta, error) {
+		var recipientID string
+		var likedBack bool
+		if err := row.Scan(&recipientID, &likedBack); err != nil {
+			return counterDelta{}, err
+		}
+		return visibilityCounterDelta(recipientID, likedBack, hidden), nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := updateCounters(ctx, tx, mergeCounterDeltas(deltas)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// visibilityCounterDelta returns the change to the recipient's
+// counters caused by hiding (or showing) an actor who likes them.
+// likedBack reports whether the recipient likes the actor.
+func visibilityCounterDelta(recipientID string, likedBack, hidden bool) counterDelta {
+	sign := int64(1)
+	if hidden {
+		sign = -1
+	}
+	d := counterDelta{userID: recipientID, likes: sign}
+	if !likedBack {
+		d.newLikes = sign
+	}
+	return d
+}
+
+// SetUserVisibility hides or shows the user; see
+// Store.SetUserVisibility.
+func (s *MemoryStore) SetUserVisibility(ctx context.Context, userID string, hidden bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hidden[userID] == hidden {
+		return nil
+	}
+	if hidden {
+		s.hidden[userID] = true
+	} else {
+		delete(s.hidden, userID)
+	}
+	var deltas []counterDelta
+	for recipientID, byActor := range s.received {
+		if !byActor[userID].liked || s.blockedLocked(userID, recipientID) {
+			continue
+		}
+		deltas = append(deltas, visibilityCounterDelta(recipientID, s.received[userID][recipientID].liked, hidden))
+	}
+	s.addCountersLocked(deltas)
+	return nil
+}
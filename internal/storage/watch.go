@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// likedYouChannel is the LISTEN/NOTIFY channel that PutDecision
+// notifies with the recipient id whenever the recipient's likers
+// change.
+const likedYouChannel = "explore_liked_you"
+
+// recipientLockClass is the first key of the two-key advisory lock
+// that serialises the history of a recipient.  Two-key locks do not
+// conflict with the single-key pair locks.
+const recipientLockClass int32 = 1
+
+// resumeTokenVersion is the first byte of an encoded resume token.  It
+// differs from cursorVersion so that pagination tokens are not
+// accepted as resume tokens and vice versa.
+const resumeTokenVersion byte = 0x80
+
+// EncodeResumeToken returns the opaque token for a LikeChange
+// sequence number.
+func EncodeResumeToken(seq int64) string {
+	var buf [9]byte
+	buf[0] = resumeTokenVersion
+	binary.BigEndian.PutUint64(buf[1:], uint64(seq))
+	return base64.RawURLEncoding.EncodeToString(buf[:])
+}
+
+// ParseResumeToken decodes a token produced by EncodeResumeToken.
+func ParseResumeToken(token string) (int64, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) != 9 || buf[0] != resumeTokenVersion {
+		return 0, ErrInvalidCursor
+	}
+	seq := int64(binary.BigEndian.Uint64(buf[1:]))
+	if seq < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return seq, nil
+}
+
+// likeHub fans notifications about a recipient out to the subscribers
+// watching that recipient.  Sends never block: each subscriber channel
+// buffers a single pending notification and further ones are dropped
+// until it has been received, so a slow watcher cannot hold up the
+// others.
+type likeHub struct {
+	// start, if set, is called when the first subscriber arrives and
+	// returns a function that is called when the last one leaves.
+	start func() (stop func())
+
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+	n    int
+	stop func()
+}
+
+// subscribe registers a subscriber for recipientID.
+func (h *likeHub) subscribe(recipientID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs == nil {
+		h.subs = make(map[string]map[chan struct{}]struct{})
+	}
+	if h.subs[recipientID] == nil {
+		h.subs[recipientID] = make(map[chan struct{}]struct{})
+	}
+	h.subs[recipientID][ch] = struct{}{}
+	h.n++
+	if h.n == 1 && h.start != nil {
+		h.stop = h.start()
+	}
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() { h.unsubscribe(recipientID, ch) })
+	}
+}
+
+func (h *likeHub) unsubscribe(recipientID string, ch chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs[recipientID], ch)
+	if len(h.subs[recipientID]) == 0 {
+		delete(h.subs, recipientID)
+	}
+	h.n--
+	if h.n == 0 && h.stop != nil {
+		h.stop()
+		h.stop = nil
+	}
+}
+
+// notify wakes the subscribers of recipientID.
+func (h *likeHub) notify(recipientID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[recipientID] {
+		wake(ch)
+	}
+}
+
+// notifyAll wakes every subscriber.  It is used when notifications
+// may have been lost.
+func (h *likeHub) notifyAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subs := range h.subs {
+		for ch := range subs {
+			wake(ch)
+		}
+	}
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// ListLikeChanges returns up to limit changes to the recipient's
+// likers after the given sequence number, oldest first.  The sequence
+// number is the decision_events id: every like is a change, and so is
+// a pass whose previous decision for the pair was a like.  Changes by
+// actors the recipient currently has a block with, and by actors who
+// are hidden, are left out.
+func (s *Store) ListLikeChanges(ctx context.Context, recipientID string, after int64, limit int) ([]LikeChange, error) {
+	ctx = withOperation(ctx, "ListLikeChanges")
+	if limit <= 0 {
+		return nil, errors.New("limit must be positive")
+	}
+	const query = `
+SELECT e.id, e.actor_user_id, e.liked_recipient, e.super_like, e.created_at
+FROM decision_events e
+WHERE e.recipient_user_id = $1 AND e.id > $2
+  AND (e.liked_recipient OR COALESCE((
+      SELECT p.liked_recipient
+      FROM decision_events p
+      WHERE p.actor_user_id = e.actor_user_id AND p.recipient_user_id = e.recipient_user_id AND p.id < e.id
+      ORDER BY p.id DESC
+      LIMIT 1), FALSE))
+  AND NOT EXISTS (SELECT 1 FROM blocked_pairs b WHERE b.user_id = $1 AND b.other_user_id = e.actor_user_id)
+  AND NOT EXISTS (SELECT 1 FROM user_status s WHERE s.user_id = e.actor_user_id AND s.hidden)
+ORDER BY e.id
+LIMIT $3;
+    `
+	rows, err := s.pool.Query(ctx, query, recipientID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	changes := make([]LikeChange, 0)
+	for rows.Next() {
+		var c LikeChange
+		var createdAt time.Time
+		if err := rows.Scan(&c.Seq, &c.ActorID, &c.Liked, &c.SuperLike, &createdAt); err != nil {
+			return nil, err
+		}
+		c.Unix = unixSeconds(createdAt)
+		changes = append(changes, c)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return changes, nil
+}
+
+// LatestLikeChange returns the id of the recipient's most recent
+// decision event, or 0 if there is none.
+func (s *Store) LatestLikeChange(ctx context.Context, recipientID string) (int64, error) {
+	ctx = withOperation(ctx, "LatestLikeChange")
+	const query = `
+SELECT COALESCE(MAX(id), 0)
+FROM decision_events
+WHERE recipient_user_id = $1;
+    `
+	var seq int64
+	err := s.pool.QueryRow(ctx, query, recipientID).Scan(&seq)
+	return seq, err
+}
+
+// SubscribeLikedYou subscribes to changes to the recipient's likers.
+// A connection listening for PutDecision notifications is held while
+// there is at least one subscriber.
+func (s *Store) SubscribeLikedYou(recipientID string) (<-chan struct{}, func()) {
+	return s.likes.subscribe(recipientID)
+}
+
+// startListener starts the notification listener and returns the
+// function that stops it.
+func (s *Store) startListener() func() {
+	ctx, cancel := context.WithCancel(withOperation(context.Background(), "SubscribeLikedYou"))
+	go s.listen(ctx)
+	return cancel
+}
+
+// listen forwards notifications to the subscribers until ctx is done,
+// reconnecting after errors.
+func (s *Store) listen(ctx context.Context) {
+	for {
+		err := s.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("storage: liked-you listener failed: %v", err)
+		// Notifications sent while disconnected are lost, so have
+		// every subscriber check for changes.
+		s.likes.notifyAll()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// listenOnce listens on a dedicated connection until an error occurs.
+func (s *Store) listenOnce(ctx context.Context) error {
+	pooled, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection stays subscribed to the channel, so it is taken
+	// out of the pool and closed when done.
+	conn := pooled.Hijack()
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+	if _, err := conn.Exec(ctx, `LISTEN `+likedYouChannel+`;`); err != nil {
+		return err
+	}
+	// Changes committed before LISTEN took effect were not notified.
+	s.likes.notifyAll()
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		s.likes.notify(n.Payload)
+	}
+}
@@ -0,0 +1,108 @@
+// Package sweeper removes expired passes in the background so that
+// the recipients resurface in the actors' decks.
+package sweeper
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Source is the store whose expired passes are swept.  It is
+// implemented by storage.Store and storage.MemoryStore.
+type Source interface {
+	// DeleteExpiredPasses deletes expired passes batchSize at a time
+	// and returns how many were deleted.
+	DeleteExpiredPasses(ctx context.Context, batchSize int) (int64, error)
+}
+
+// Sweeper periodically deletes a Source's expired passes and records
+// the outcome in Prometheus metrics.
+type Sweeper struct {
+	source    Source
+	interval  time.Duration
+	batchSize int
+
+	deleted     prometheus.Counter
+	sweeps      *prometheus.CounterVec
+	duration    prometheus.Histogram
+	lastSuccess prometheus.Gauge
+}
+
+// New constructs a Sweeper that sweeps source every interval, deleting
+// up to batchSize passes per transaction.  Sensible defaults of one
+// minute and 1000 passes are used for non-positive values.  The
+// metrics are registered with reg unless it is nil.
+func New(source Source, interval time.Duration, batchSize int, reg prometheus.Registerer) *Sweeper {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	s := &Sweeper{
+		source:    source,
+		interval:  interval,
+		batchSize: batchSize,
+		deleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "explore_pass_sweeper_deleted_passes_total",
+			Help: "Number of expired passes deleted by the pass sweeper.",
+		}),
+		sweeps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "explore_pass_sweeper_sweeps_total",
+			Help: "Number of pass sweeps by result (ok or error).",
+		}, []string{"result"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "explore_pass_sweeper_sweep_duration_seconds",
+			Help:    "Time taken by a pass sweep.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		lastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "explore_pass_sweeper_last_success_timestamp_seconds",
+			Help: "Unix time of the last pass sweep that completed without error.",
+		}),
+	}
+	if reg != nil {
+		reg.MustRegister(s.deleted, s.sweeps, s.duration, s.lastSuccess)
+	}
+	return s
+}
+
+// Run sweeps every interval until ctx is done.  Errors are logged and
+// the sweep is retried on the next tick.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		n, err := s.Sweep(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Printf("sweeper: deleted %d expired pass(es) before error: %v", n, err)
+		case n > 0:
+			log.Printf("sweeper: deleted %d expired pass(es)", n)
+		}
+	}
+}
+
+// Sweep deletes every expired pass and returns how many were deleted.
+// Passes deleted before an error are counted as well.
+func (s *Sweeper) Sweep(ctx context.Context) (int64, error) {
+	start := time.Now()
+	n, err := s.source.DeleteExpiredPasses(ctx, s.batchSize)
+	s.duration.Observe(time.Since(start).Seconds())
+	s.deleted.Add(float64(n))
+	if err != nil {
+		s.sweeps.WithLabelValues("error").Inc()
+		return n, err
+	}
+	s.sweeps.WithLabelValues("ok").Inc()
+	s.lastSuccess.SetToCurrentTime()
+	return n, nil
+}
@@ -1,296 +1,3173 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: explore-service.proto
+
 package explorepb
 
 import (
-	context "context"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Order selects the direction of a listing by time.
+type Order int32
+
+const (
+	// The listing's default order, or the order recorded in the
+	// pagination token.
+	Order_ORDER_UNSPECIFIED  Order = 0
+	Order_ORDER_NEWEST_FIRST Order = 1
+	Order_ORDER_OLDEST_FIRST Order = 2
+)
+
+// Enum value maps for Order.
+var (
+	Order_name = map[int32]string{
+		0: "ORDER_UNSPECIFIED",
+		1: "ORDER_NEWEST_FIRST",
+		2: "ORDER_OLDEST_FIRST",
+	}
+	Order_value = map[string]int32{
+		"ORDER_UNSPECIFIED":  0,
+		"ORDER_NEWEST_FIRST": 1,
+		"ORDER_OLDEST_FIRST": 2,
+	}
+)
+
+func (x Order) Enum() *Order {
+	p := new(Order)
+	*p = x
+	return p
+}
+
+func (x Order) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Order) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Order) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[0]
+}
+
+func (x Order) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Order.Descriptor instead.
+func (Order) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{0}
+}
+
+// DecisionType is the kind of decision an actor makes.  A super like is
+// a like that is flagged to the recipient and limited by a daily
+// quota.  Requests that leave it unspecified fall back to
+// liked_recipient, so clients that only send the bool keep working.
+// Responses set both, with liked_recipient true for likes and super
+// likes.
+type DecisionType int32
+
+const (
+	DecisionType_DECISION_TYPE_UNSPECIFIED DecisionType = 0
+	DecisionType_DECISION_TYPE_PASS        DecisionType = 1
+	DecisionType_DECISION_TYPE_LIKE        DecisionType = 2
+	DecisionType_DECISION_TYPE_SUPER_LIKE  DecisionType = 3
+)
+
+// Enum value maps for DecisionType.
+var (
+	DecisionType_name = map[int32]string{
+		0: "DECISION_TYPE_UNSPECIFIED",
+		1: "DECISION_TYPE_PASS",
+		2: "DECISION_TYPE_LIKE",
+		3: "DECISION_TYPE_SUPER_LIKE",
+	}
+	DecisionType_value = map[string]int32{
+		"DECISION_TYPE_UNSPECIFIED": 0,
+		"DECISION_TYPE_PASS":        1,
+		"DECISION_TYPE_LIKE":        2,
+		"DECISION_TYPE_SUPER_LIKE":  3,
+	}
+)
+
+func (x DecisionType) Enum() *DecisionType {
+	p := new(DecisionType)
+	*p = x
+	return p
+}
 
-	proto "github.com/golang/protobuf/proto"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
+func (x DecisionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DecisionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[1].Descriptor()
+}
+
+func (DecisionType) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[1]
+}
+
+func (x DecisionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DecisionType.Descriptor instead.
+func (DecisionType) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{1}
+}
+
+// DecisionFilter selects the decisions listed by ListMyDecisions.
+type DecisionFilter int32
+
+const (
+	DecisionFilter_DECISION_FILTER_ALL    DecisionFilter = 0
+	DecisionFilter_DECISION_FILTER_LIKED  DecisionFilter = 1
+	DecisionFilter_DECISION_FILTER_PASSED DecisionFilter = 2
+)
+
+// Enum value maps for DecisionFilter.
+var (
+	DecisionFilter_name = map[int32]string{
+		0: "DECISION_FILTER_ALL",
+		1: "DECISION_FILTER_LIKED",
+		2: "DECISION_FILTER_PASSED",
+	}
+	DecisionFilter_value = map[string]int32{
+		"DECISION_FILTER_ALL":    0,
+		"DECISION_FILTER_LIKED":  1,
+		"DECISION_FILTER_PASSED": 2,
+	}
 )
 
-// Ensure the generated code is sufficiently recent.
-const _ = proto.ProtoPackageIsVersion4
+func (x DecisionFilter) Enum() *DecisionFilter {
+	p := new(DecisionFilter)
+	*p = x
+	return p
+}
+
+func (x DecisionFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DecisionFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[2].Descriptor()
+}
+
+func (DecisionFilter) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[2]
+}
+
+func (x DecisionFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DecisionFilter.Descriptor instead.
+func (DecisionFilter) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{2}
+}
 
-// ListLikedYouRequest is the input message for ListLikedYou and
-// ListNewLikedYou RPCs.
+// The recipient_user_id is the
+// identifier of the user whose admirers are being listed.  page_size
+// limits the number of likers returned; it defaults to the server's
+// page size and is clamped to the server's maximum.  order defaults to
+// newest first.  A pagination_token continues the listing in the order
+// it was started with, so order must be left unspecified or match it.
+// since_unix_timestamp and until_unix_timestamp restrict the listing
+// to likers whose unix_timestamp t satisfies since <= t < until; either
+// bound may be omitted.
 type ListLikedYouRequest struct {
-	RecipientUserId string  `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
-	PaginationToken *string `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId    string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	PaginationToken    *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize           *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	Order              Order                  `protobuf:"varint,4,opt,name=order,proto3,enum=explore.Order" json:"order,omitempty"`
+	SinceUnixTimestamp *uint64                `protobuf:"varint,5,opt,name=since_unix_timestamp,json=sinceUnixTimestamp,proto3,oneof" json:"since_unix_timestamp,omitempty"`
+	UntilUnixTimestamp *uint64                `protobuf:"varint,6,opt,name=until_unix_timestamp,json=untilUnixTimestamp,proto3,oneof" json:"until_unix_timestamp,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListLikedYouRequest) Reset() {
+	*x = ListLikedYouRequest{}
+	mi := &file_explore_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedYouRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedYouRequest) ProtoMessage() {}
+
+func (x *ListLikedYouRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *ListLikedYouRequest) Reset()         { *m = ListLikedYouRequest{} }
-func (m *ListLikedYouRequest) String() string { return proto.CompactTextString(m) }
-func (*ListLikedYouRequest) ProtoMessage()    {}
+// Deprecated: Use ListLikedYouRequest.ProtoReflect.Descriptor instead.
+func (*ListLikedYouRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{0}
+}
 
-func (m *ListLikedYouRequest) GetRecipientUserId() string {
-	if m != nil {
-		return m.RecipientUserId
+func (x *ListLikedYouRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
 	}
 	return ""
 }
 
-func (m *ListLikedYouRequest) GetPaginationToken() string {
-	if m != nil && m.PaginationToken != nil {
-		return *m.PaginationToken
+func (x *ListLikedYouRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
 	}
 	return ""
 }
 
-// ListLikedYouResponse_Liker represents a single like.
-type ListLikedYouResponse_Liker struct {
-	ActorId       string `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
-	UnixTimestamp uint64 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+func (x *ListLikedYouRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
 }
 
-func (m *ListLikedYouResponse_Liker) Reset()         { *m = ListLikedYouResponse_Liker{} }
-func (m *ListLikedYouResponse_Liker) String() string { return proto.CompactTextString(m) }
-func (*ListLikedYouResponse_Liker) ProtoMessage()    {}
+func (x *ListLikedYouRequest) GetOrder() Order {
+	if x != nil {
+		return x.Order
+	}
+	return Order_ORDER_UNSPECIFIED
+}
 
-func (m *ListLikedYouResponse_Liker) GetActorId() string {
-	if m != nil {
-		return m.ActorId
+func (x *ListLikedYouRequest) GetSinceUnixTimestamp() uint64 {
+	if x != nil && x.SinceUnixTimestamp != nil {
+		return *x.SinceUnixTimestamp
 	}
-	return ""
+	return 0
 }
 
-func (m *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
-	if m != nil {
-		return m.UnixTimestamp
+func (x *ListLikedYouRequest) GetUntilUnixTimestamp() uint64 {
+	if x != nil && x.UntilUnixTimestamp != nil {
+		return *x.UntilUnixTimestamp
 	}
 	return 0
 }
 
-// ListLikedYouResponse is the response for list RPCs.
+// Response message for the list RPCs.  Each liker contains the
+// identifier of the actor, a unix timestamp indicating when the
+// decision was last updated and whether the like is a super like.  If
+// there are more results the next_pagination_token will be set.
 type ListLikedYouResponse struct {
+	state               protoimpl.MessageState        `protogen:"open.v1"`
 	Likers              []*ListLikedYouResponse_Liker `protobuf:"bytes,1,rep,name=likers,proto3" json:"likers,omitempty"`
 	NextPaginationToken *string                       `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListLikedYouResponse) Reset() {
+	*x = ListLikedYouResponse{}
+	mi := &file_explore_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedYouResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedYouResponse) ProtoMessage() {}
+
+func (x *ListLikedYouResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *ListLikedYouResponse) Reset()         { *m = ListLikedYouResponse{} }
-func (m *ListLikedYouResponse) String() string { return proto.CompactTextString(m) }
-func (*ListLikedYouResponse) ProtoMessage()    {}
+// Deprecated: Use ListLikedYouResponse.ProtoReflect.Descriptor instead.
+func (*ListLikedYouResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{1}
+}
 
-func (m *ListLikedYouResponse) GetLikers() []*ListLikedYouResponse_Liker {
-	if m != nil {
-		return m.Likers
+func (x *ListLikedYouResponse) GetLikers() []*ListLikedYouResponse_Liker {
+	if x != nil {
+		return x.Likers
 	}
 	return nil
 }
 
-func (m *ListLikedYouResponse) GetNextPaginationToken() string {
-	if m != nil && m.NextPaginationToken != nil {
-		return *m.NextPaginationToken
+func (x *ListLikedYouResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
 	}
 	return ""
 }
 
-// CountLikedYouRequest is the input for CountLikedYou RPC.
+// Request message for the count RPC.  Only the recipient's id is
+// required.  The optional bounds restrict the count like they restrict
+// ListLikedYou.
 type CountLikedYouRequest struct {
-	RecipientUserId string `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId    string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	SinceUnixTimestamp *uint64                `protobuf:"varint,2,opt,name=since_unix_timestamp,json=sinceUnixTimestamp,proto3,oneof" json:"since_unix_timestamp,omitempty"`
+	UntilUnixTimestamp *uint64                `protobuf:"varint,3,opt,name=until_unix_timestamp,json=untilUnixTimestamp,proto3,oneof" json:"until_unix_timestamp,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CountLikedYouRequest) Reset() {
+	*x = CountLikedYouRequest{}
+	mi := &file_explore_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountLikedYouRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountLikedYouRequest) ProtoMessage() {}
+
+func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *CountLikedYouRequest) Reset()         { *m = CountLikedYouRequest{} }
-func (m *CountLikedYouRequest) String() string { return proto.CompactTextString(m) }
-func (*CountLikedYouRequest) ProtoMessage()    {}
+// Deprecated: Use CountLikedYouRequest.ProtoReflect.Descriptor instead.
+func (*CountLikedYouRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{2}
+}
 
-func (m *CountLikedYouRequest) GetRecipientUserId() string {
-	if m != nil {
-		return m.RecipientUserId
+func (x *CountLikedYouRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
 	}
 	return ""
 }
 
-// CountLikedYouResponse returns a count.
+func (x *CountLikedYouRequest) GetSinceUnixTimestamp() uint64 {
+	if x != nil && x.SinceUnixTimestamp != nil {
+		return *x.SinceUnixTimestamp
+	}
+	return 0
+}
+
+func (x *CountLikedYouRequest) GetUntilUnixTimestamp() uint64 {
+	if x != nil && x.UntilUnixTimestamp != nil {
+		return *x.UntilUnixTimestamp
+	}
+	return 0
+}
+
+// Response message containing the count of likers.
 type CountLikedYouResponse struct {
-	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountLikedYouResponse) Reset() {
+	*x = CountLikedYouResponse{}
+	mi := &file_explore_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountLikedYouResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountLikedYouResponse) ProtoMessage() {}
+
+func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountLikedYouResponse.ProtoReflect.Descriptor instead.
+func (*CountLikedYouResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CountLikedYouResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Request message for GetLikeSummary.
+type GetLikeSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikeSummaryRequest) Reset() {
+	*x = GetLikeSummaryRequest{}
+	mi := &file_explore_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikeSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikeSummaryRequest) ProtoMessage() {}
+
+func (x *GetLikeSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikeSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetLikeSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLikeSummaryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Response message for GetLikeSummary.  likes and new_likes match
+// CountLikedYou and CountNewLikedYou; matches is the number of users
+// ListMatches would return.
+type GetLikeSummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         uint64                 `protobuf:"varint,1,opt,name=likes,proto3" json:"likes,omitempty"`
+	NewLikes      uint64                 `protobuf:"varint,2,opt,name=new_likes,json=newLikes,proto3" json:"new_likes,omitempty"`
+	Matches       uint64                 `protobuf:"varint,3,opt,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLikeSummaryResponse) Reset() {
+	*x = GetLikeSummaryResponse{}
+	mi := &file_explore_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLikeSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLikeSummaryResponse) ProtoMessage() {}
+
+func (x *GetLikeSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLikeSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetLikeSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetLikeSummaryResponse) GetLikes() uint64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
 }
 
-func (m *CountLikedYouResponse) Reset()         { *m = CountLikedYouResponse{} }
-func (m *CountLikedYouResponse) String() string { return proto.CompactTextString(m) }
-func (*CountLikedYouResponse) ProtoMessage()    {}
+func (x *GetLikeSummaryResponse) GetNewLikes() uint64 {
+	if x != nil {
+		return x.NewLikes
+	}
+	return 0
+}
 
-func (m *CountLikedYouResponse) GetCount() uint64 {
-	if m != nil {
-		return m.Count
+func (x *GetLikeSummaryResponse) GetMatches() uint64 {
+	if x != nil {
+		return x.Matches
 	}
 	return 0
 }
 
-// PutDecisionRequest records a like or pass.
+// Request message for recording a decision.  actor_user_id is the id
+// of the user making the decision and recipient_user_id is the id of
+// the user receiving it.  decision_type selects the decision; if it is
+// unspecified, liked_recipient true records a like and false a pass.
+// Setting liked_recipient together with DECISION_TYPE_PASS is
+// rejected.  idempotency_key, chosen by the client and unique per
+// actor, makes the call safe to retry: while the server remembers the
+// key, repeating the request returns the original result without
+// recording the decision again, and using the key for a different
+// decision fails with FAILED_PRECONDITION.
 type PutDecisionRequest struct {
-	ActorUserId     string `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
-	RecipientUserId string `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
-	LikedRecipient  bool   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	IdempotencyKey  *string                `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	DecisionType    DecisionType           `protobuf:"varint,5,opt,name=decision_type,json=decisionType,proto3,enum=explore.DecisionType" json:"decision_type,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PutDecisionRequest) Reset() {
+	*x = PutDecisionRequest{}
+	mi := &file_explore_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionRequest) ProtoMessage() {}
+
+func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *PutDecisionRequest) Reset()         { *m = PutDecisionRequest{} }
-func (m *PutDecisionRequest) String() string { return proto.CompactTextString(m) }
-func (*PutDecisionRequest) ProtoMessage()    {}
+// Deprecated: Use PutDecisionRequest.ProtoReflect.Descriptor instead.
+func (*PutDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{6}
+}
 
-func (m *PutDecisionRequest) GetActorUserId() string {
-	if m != nil {
-		return m.ActorUserId
+func (x *PutDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
 	}
 	return ""
 }
 
-func (m *PutDecisionRequest) GetRecipientUserId() string {
-	if m != nil {
-		return m.RecipientUserId
+func (x *PutDecisionRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
 	}
 	return ""
 }
 
-func (m *PutDecisionRequest) GetLikedRecipient() bool {
-	if m != nil {
-		return m.LikedRecipient
+func (x *PutDecisionRequest) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
 	}
 	return false
 }
 
-// PutDecisionResponse returns whether the like is mutual.
+func (x *PutDecisionRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PutDecisionRequest) GetDecisionType() DecisionType {
+	if x != nil {
+		return x.DecisionType
+	}
+	return DecisionType_DECISION_TYPE_UNSPECIFIED
+}
+
+// Response message for PutDecision.  mutual_likes will be set to true
+// if both actor and recipient have liked each other at the time of
+// recording the decision.
 type PutDecisionResponse struct {
-	MutualLikes bool `protobuf:"varint,1,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"`
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MutualLikes   bool                   `protobuf:"varint,1,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (m *PutDecisionResponse) Reset()         { *m = PutDecisionResponse{} }
-func (m *PutDecisionResponse) String() string { return proto.CompactTextString(m) }
-func (*PutDecisionResponse) ProtoMessage()    {}
+func (x *PutDecisionResponse) Reset() {
+	*x = PutDecisionResponse{}
+	mi := &file_explore_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (m *PutDecisionResponse) GetMutualLikes() bool {
-	if m != nil {
-		return m.MutualLikes
-	}
-	return false
+func (x *PutDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-// ExploreServiceServer defines the server API for ExploreService.
-// All implementations must embed UnimplementedExploreServiceServer for
-// forward compatibility.
-type ExploreServiceServer interface {
-	ListLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
-	ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
-	CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error)
-	PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error)
+func (*PutDecisionResponse) ProtoMessage() {}
+
+func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-// UnimplementedExploreServiceServer can be embedded to have forward
-// compatible implementations.
-type UnimplementedExploreServiceServer struct{}
+// Deprecated: Use PutDecisionResponse.ProtoReflect.Descriptor instead.
+func (*PutDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{7}
+}
 
-func (*UnimplementedExploreServiceServer) ListLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListLikedYou not implemented")
+func (x *PutDecisionResponse) GetMutualLikes() bool {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return false
 }
-func (*UnimplementedExploreServiceServer) ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListNewLikedYou not implemented")
+
+// Request message for PutDecisions.  actor_user_id is the id of the
+// user making every decision; decisions are applied in the order
+// given, so a later decision on the same recipient overwrites an
+// earlier one.  Each decision is selected as in PutDecisionRequest.
+type PutDecisionsRequest struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	ActorUserId   string                          `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	Decisions     []*PutDecisionsRequest_Decision `protobuf:"bytes,2,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
-func (*UnimplementedExploreServiceServer) CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CountLikedYou not implemented")
+
+func (x *PutDecisionsRequest) Reset() {
+	*x = PutDecisionsRequest{}
+	mi := &file_explore_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
-func (*UnimplementedExploreServiceServer) PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PutDecision not implemented")
+
+func (x *PutDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-// RegisterExploreServiceServer registers the service implementation with a gRPC server.
-func RegisterExploreServiceServer(s *grpc.Server, srv ExploreServiceServer) {
-	s.RegisterService(&ExploreService_ServiceDesc, srv)
+func (*PutDecisionsRequest) ProtoMessage() {}
+
+func (x *PutDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-// ExploreService_ServiceDesc is the grpc.ServiceDesc for ExploreService service.
-var ExploreService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "explore.ExploreService",
-	HandlerType: (*ExploreServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListLikedYou",
-			Handler:    _ExploreService_ListLikedYou_Handler,
-		},
-		{
-			MethodName: "ListNewLikedYou",
-			Handler:    _ExploreService_ListNewLikedYou_Handler,
-		},
-		{
-			MethodName: "CountLikedYou",
-			Handler:    _ExploreService_CountLikedYou_Handler,
-		},
-		{
-			MethodName: "PutDecision",
-			Handler:    _ExploreService_PutDecision_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/explore-service.proto",
+// Deprecated: Use PutDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*PutDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{8}
 }
 
-func _ExploreService_ListLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListLikedYouRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *PutDecisionsRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
 	}
-	if interceptor == nil {
-		return srv.(ExploreServiceServer).ListLikedYou(ctx, in)
+	return ""
+}
+
+func (x *PutDecisionsRequest) GetDecisions() []*PutDecisionsRequest_Decision {
+	if x != nil {
+		return x.Decisions
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/explore.ExploreService/ListLikedYou",
+	return nil
+}
+
+// Response message for PutDecisions, with one result per decision in
+// request order.  code is a google.rpc.Code value: OK (0) if the
+// decision was recorded, in which case mutual_likes is set as by
+// PutDecision, and otherwise the reason it was not, described by
+// error_message.
+type PutDecisionsResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Results       []*PutDecisionsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutDecisionsResponse) Reset() {
+	*x = PutDecisionsResponse{}
+	mi := &file_explore_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsResponse) ProtoMessage() {}
+
+func (x *PutDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExploreServiceServer).ListLikedYou(ctx, req.(*ListLikedYouRequest))
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*PutDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PutDecisionsResponse) GetResults() []*PutDecisionsResponse_Result {
+	if x != nil {
+		return x.Results
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _ExploreService_ListNewLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListLikedYouRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+// Request message for RewindDecision.
+type RewindDecisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId   string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewindDecisionRequest) Reset() {
+	*x = RewindDecisionRequest{}
+	mi := &file_explore_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewindDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewindDecisionRequest) ProtoMessage() {}
+
+func (x *RewindDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if interceptor == nil {
-		return srv.(ExploreServiceServer).ListNewLikedYou(ctx, in)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewindDecisionRequest.ProtoReflect.Descriptor instead.
+func (*RewindDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RewindDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/explore.ExploreService/ListNewLikedYou",
+	return ""
+}
+
+// Response message for RewindDecision.  recipient_user_id and
+// liked_recipient describe the decision that was undone.
+// restored_liked_recipient and restored_decision_type are the decision
+// now in effect on the same recipient and are unset if the actor no
+// longer has one.
+type RewindDecisionResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId        string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient         bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	RestoredLikedRecipient *bool                  `protobuf:"varint,3,opt,name=restored_liked_recipient,json=restoredLikedRecipient,proto3,oneof" json:"restored_liked_recipient,omitempty"`
+	RestoredDecisionType   *DecisionType          `protobuf:"varint,4,opt,name=restored_decision_type,json=restoredDecisionType,proto3,enum=explore.DecisionType,oneof" json:"restored_decision_type,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RewindDecisionResponse) Reset() {
+	*x = RewindDecisionResponse{}
+	mi := &file_explore_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewindDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewindDecisionResponse) ProtoMessage() {}
+
+func (x *RewindDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExploreServiceServer).ListNewLikedYou(ctx, req.(*ListLikedYouRequest))
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewindDecisionResponse.ProtoReflect.Descriptor instead.
+func (*RewindDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RewindDecisionResponse) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _ExploreService_CountLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CountLikedYouRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *RewindDecisionResponse) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
 	}
-	if interceptor == nil {
-		return srv.(ExploreServiceServer).CountLikedYou(ctx, in)
+	return false
+}
+
+func (x *RewindDecisionResponse) GetRestoredLikedRecipient() bool {
+	if x != nil && x.RestoredLikedRecipient != nil {
+		return *x.RestoredLikedRecipient
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/explore.ExploreService/CountLikedYou",
+	return false
+}
+
+func (x *RewindDecisionResponse) GetRestoredDecisionType() DecisionType {
+	if x != nil && x.RestoredDecisionType != nil {
+		return *x.RestoredDecisionType
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExploreServiceServer).CountLikedYou(ctx, req.(*CountLikedYouRequest))
+	return DecisionType_DECISION_TYPE_UNSPECIFIED
+}
+
+// Request message for ListMatches.  user_id is the user whose matches
+// are being listed.
+type ListMatchesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMatchesRequest) Reset() {
+	*x = ListMatchesRequest{}
+	mi := &file_explore_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesRequest) ProtoMessage() {}
+
+func (x *ListMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return interceptor(ctx, in, info, handler)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{12}
 }
 
-func _ExploreService_PutDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PutDecisionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *ListMatchesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
-	if interceptor == nil {
-		return srv.(ExploreServiceServer).PutDecision(ctx, in)
+	return ""
+}
+
+func (x *ListMatchesRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/explore.ExploreService/PutDecision",
+	return ""
+}
+
+// Response message for ListMatches.  Each match contains the
+// identifier of the other user and a unix timestamp indicating when
+// the match was created.  If there are more results the
+// next_pagination_token will be set.
+type ListMatchesResponse struct {
+	state               protoimpl.MessageState       `protogen:"open.v1"`
+	Matches             []*ListMatchesResponse_Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	NextPaginationToken *string                      `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse) Reset() {
+	*x = ListMatchesResponse{}
+	mi := &file_explore_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse) ProtoMessage() {}
+
+func (x *ListMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ExploreServiceServer).PutDecision(ctx, req.(*PutDecisionRequest))
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListMatchesResponse) GetMatches() []*ListMatchesResponse_Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ListMatchesResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+// Request message for GetDecisionHistory.
+type GetDecisionHistoryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDecisionHistoryRequest) Reset() {
+	*x = GetDecisionHistoryRequest{}
+	mi := &file_explore_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionHistoryRequest) ProtoMessage() {}
+
+func (x *GetDecisionHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetDecisionHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetDecisionHistoryRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionHistoryRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+// Response message for GetDecisionHistory.  Each event records whether
+// the actor liked the recipient, the type of decision and a unix
+// timestamp indicating when the decision was made.  Events are ordered
+// oldest first.
+type GetDecisionHistoryResponse struct {
+	state         protoimpl.MessageState                      `protogen:"open.v1"`
+	Events        []*GetDecisionHistoryResponse_DecisionEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDecisionHistoryResponse) Reset() {
+	*x = GetDecisionHistoryResponse{}
+	mi := &file_explore_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionHistoryResponse) ProtoMessage() {}
+
+func (x *GetDecisionHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetDecisionHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetDecisionHistoryResponse) GetEvents() []*GetDecisionHistoryResponse_DecisionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Request message for GetDecision.
+type GetDecisionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDecisionRequest) Reset() {
+	*x = GetDecisionRequest{}
+	mi := &file_explore_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionRequest) ProtoMessage() {}
+
+func (x *GetDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionRequest.ProtoReflect.Descriptor instead.
+func (*GetDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+// Response message for GetDecision.  decided is false if the actor has
+// not decided on the recipient, in which case the other fields are
+// unset.  unix_timestamp is when the decision was last updated.
+type GetDecisionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Decided        bool                   `protobuf:"varint,1,opt,name=decided,proto3" json:"decided,omitempty"`
+	LikedRecipient bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	UnixTimestamp  uint64                 `protobuf:"varint,3,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	DecisionType   DecisionType           `protobuf:"varint,4,opt,name=decision_type,json=decisionType,proto3,enum=explore.DecisionType" json:"decision_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDecisionResponse) Reset() {
+	*x = GetDecisionResponse{}
+	mi := &file_explore_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionResponse) ProtoMessage() {}
+
+func (x *GetDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionResponse.ProtoReflect.Descriptor instead.
+func (*GetDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetDecisionResponse) GetDecided() bool {
+	if x != nil {
+		return x.Decided
+	}
+	return false
+}
+
+func (x *GetDecisionResponse) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *GetDecisionResponse) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *GetDecisionResponse) GetDecisionType() DecisionType {
+	if x != nil {
+		return x.DecisionType
+	}
+	return DecisionType_DECISION_TYPE_UNSPECIFIED
+}
+
+// Request message for ListMyDecisions.  page_size works as in
+// ListLikedYouRequest.
+type ListMyDecisionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	Filter          DecisionFilter         `protobuf:"varint,3,opt,name=filter,proto3,enum=explore.DecisionFilter" json:"filter,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMyDecisionsRequest) Reset() {
+	*x = ListMyDecisionsRequest{}
+	mi := &file_explore_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyDecisionsRequest) ProtoMessage() {}
+
+func (x *ListMyDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListMyDecisionsRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListMyDecisionsRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListMyDecisionsRequest) GetFilter() DecisionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return DecisionFilter_DECISION_FILTER_ALL
+}
+
+func (x *ListMyDecisionsRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+// Response message for ListMyDecisions.  Each decision contains the
+// recipient, whether they were liked, the type of decision and a unix
+// timestamp indicating when the decision was last updated.  If there
+// are more results the next_pagination_token will be set.
+type ListMyDecisionsResponse struct {
+	state               protoimpl.MessageState              `protogen:"open.v1"`
+	Decisions           []*ListMyDecisionsResponse_Decision `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	NextPaginationToken *string                             `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListMyDecisionsResponse) Reset() {
+	*x = ListMyDecisionsResponse{}
+	mi := &file_explore_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyDecisionsResponse) ProtoMessage() {}
+
+func (x *ListMyDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListMyDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListMyDecisionsResponse) GetDecisions() []*ListMyDecisionsResponse_Decision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *ListMyDecisionsResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+// Request message for FilterUndecided.
+type FilterUndecidedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId   string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	CandidateIds  []string               `protobuf:"bytes,2,rep,name=candidate_ids,json=candidateIds,proto3" json:"candidate_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterUndecidedRequest) Reset() {
+	*x = FilterUndecidedRequest{}
+	mi := &file_explore_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterUndecidedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterUndecidedRequest) ProtoMessage() {}
+
+func (x *FilterUndecidedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterUndecidedRequest.ProtoReflect.Descriptor instead.
+func (*FilterUndecidedRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FilterUndecidedRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *FilterUndecidedRequest) GetCandidateIds() []string {
+	if x != nil {
+		return x.CandidateIds
+	}
+	return nil
+}
+
+// Response message for FilterUndecided.  undecided_ids holds the
+// candidates without a decision by the actor or a block with them, in
+// request order and without duplicates.
+type FilterUndecidedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UndecidedIds  []string               `protobuf:"bytes,1,rep,name=undecided_ids,json=undecidedIds,proto3" json:"undecided_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterUndecidedResponse) Reset() {
+	*x = FilterUndecidedResponse{}
+	mi := &file_explore_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterUndecidedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterUndecidedResponse) ProtoMessage() {}
+
+func (x *FilterUndecidedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterUndecidedResponse.ProtoReflect.Descriptor instead.
+func (*FilterUndecidedResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FilterUndecidedResponse) GetUndecidedIds() []string {
+	if x != nil {
+		return x.UndecidedIds
+	}
+	return nil
+}
+
+// Request message for BlockUser.  user_id blocks blocked_user_id.
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_explore_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BlockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+// Response message for BlockUser.
+type BlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	mi := &file_explore_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{23}
+}
+
+// Request message for UnblockUser.  user_id lifts their block of
+// blocked_user_id.
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_explore_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UnblockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnblockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+// Response message for UnblockUser.
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	mi := &file_explore_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{25}
+}
+
+// Request message for ListBlocked.  page_size works as in
+// ListLikedYouRequest.
+type ListBlockedRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32                `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListBlockedRequest) Reset() {
+	*x = ListBlockedRequest{}
+	mi := &file_explore_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRequest) ProtoMessage() {}
+
+func (x *ListBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListBlockedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListBlockedRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListBlockedRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+// Response message for ListBlocked.  Each entry contains the blocked
+// user and a unix timestamp indicating when they were blocked.  If
+// there are more results the next_pagination_token will be set.
+type ListBlockedResponse struct {
+	state               protoimpl.MessageState             `protogen:"open.v1"`
+	BlockedUsers        []*ListBlockedResponse_BlockedUser `protobuf:"bytes,1,rep,name=blocked_users,json=blockedUsers,proto3" json:"blocked_users,omitempty"`
+	NextPaginationToken *string                            `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse) Reset() {
+	*x = ListBlockedResponse{}
+	mi := &file_explore_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse) ProtoMessage() {}
+
+func (x *ListBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListBlockedResponse) GetBlockedUsers() []*ListBlockedResponse_BlockedUser {
+	if x != nil {
+		return x.BlockedUsers
+	}
+	return nil
+}
+
+func (x *ListBlockedResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+// Request message for SetUserVisibility.  hidden hides user_id; false
+// shows them again.
+type SetUserVisibilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Hidden        bool                   `protobuf:"varint,2,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserVisibilityRequest) Reset() {
+	*x = SetUserVisibilityRequest{}
+	mi := &file_explore_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserVisibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserVisibilityRequest) ProtoMessage() {}
+
+func (x *SetUserVisibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*SetUserVisibilityRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetUserVisibilityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserVisibilityRequest) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+// Response message for SetUserVisibility.
+type SetUserVisibilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserVisibilityResponse) Reset() {
+	*x = SetUserVisibilityResponse{}
+	mi := &file_explore_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserVisibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserVisibilityResponse) ProtoMessage() {}
+
+func (x *SetUserVisibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserVisibilityResponse.ProtoReflect.Descriptor instead.
+func (*SetUserVisibilityResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{29}
+}
+
+// Request message for DeleteUserData.
+type DeleteUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataRequest) Reset() {
+	*x = DeleteUserDataRequest{}
+	mi := &file_explore_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataRequest) ProtoMessage() {}
+
+func (x *DeleteUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Response message for DeleteUserData.  The counts are the rows
+// deleted by this call; completed_unix_timestamp is when the erasure
+// was recorded as complete.
+type DeleteUserDataResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Decisions              uint64                 `protobuf:"varint,1,opt,name=decisions,proto3" json:"decisions,omitempty"`
+	DecisionEvents         uint64                 `protobuf:"varint,2,opt,name=decision_events,json=decisionEvents,proto3" json:"decision_events,omitempty"`
+	Matches                uint64                 `protobuf:"varint,3,opt,name=matches,proto3" json:"matches,omitempty"`
+	Blocks                 uint64                 `protobuf:"varint,4,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	CompletedUnixTimestamp uint64                 `protobuf:"varint,5,opt,name=completed_unix_timestamp,json=completedUnixTimestamp,proto3" json:"completed_unix_timestamp,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataResponse) Reset() {
+	*x = DeleteUserDataResponse{}
+	mi := &file_explore_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataResponse) ProtoMessage() {}
+
+func (x *DeleteUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteUserDataResponse) GetDecisions() uint64 {
+	if x != nil {
+		return x.Decisions
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetDecisionEvents() uint64 {
+	if x != nil {
+		return x.DecisionEvents
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetMatches() uint64 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetBlocks() uint64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetCompletedUnixTimestamp() uint64 {
+	if x != nil {
+		return x.CompletedUnixTimestamp
+	}
+	return 0
+}
+
+// Request message for ExportUserData.
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_explore_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Response message for ExportUserData.  Each message sets one field:
+// summary holds the user's counts, decisions their current decisions,
+// history their decisions in the order they were made, matches and
+// blocked_users their matches and blocks.
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState                 `protogen:"open.v1"`
+	Summary       *GetLikeSummaryResponse                `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	Decisions     []*ListMyDecisionsResponse_Decision    `protobuf:"bytes,2,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	History       []*ExportUserDataResponse_HistoryEvent `protobuf:"bytes,3,rep,name=history,proto3" json:"history,omitempty"`
+	Matches       []*ListMatchesResponse_Match           `protobuf:"bytes,4,rep,name=matches,proto3" json:"matches,omitempty"`
+	BlockedUsers  []*ListBlockedResponse_BlockedUser     `protobuf:"bytes,5,rep,name=blocked_users,json=blockedUsers,proto3" json:"blocked_users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_explore_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportUserDataResponse) GetSummary() *GetLikeSummaryResponse {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetDecisions() []*ListMyDecisionsResponse_Decision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetHistory() []*ExportUserDataResponse_HistoryEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetMatches() []*ListMatchesResponse_Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) GetBlockedUsers() []*ListBlockedResponse_BlockedUser {
+	if x != nil {
+		return x.BlockedUsers
+	}
+	return nil
+}
+
+// Request message for WatchLikedYou.  Without a resume_token only
+// changes made after the call starts are streamed.
+type WatchLikedYouRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	ResumeToken     *string                `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3,oneof" json:"resume_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchLikedYouRequest) Reset() {
+	*x = WatchLikedYouRequest{}
+	mi := &file_explore_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLikedYouRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLikedYouRequest) ProtoMessage() {}
+
+func (x *WatchLikedYouRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLikedYouRequest.ProtoReflect.Descriptor instead.
+func (*WatchLikedYouRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *WatchLikedYouRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *WatchLikedYouRequest) GetResumeToken() string {
+	if x != nil && x.ResumeToken != nil {
+		return *x.ResumeToken
+	}
+	return ""
+}
+
+// Response message for WatchLikedYou.  Exactly one of liker and
+// removed is set.  A liker's unix_timestamp is the time of the like; a
+// removal's is the time of the pass.
+type WatchLikedYouResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Change:
+	//
+	//	*WatchLikedYouResponse_Liker
+	//	*WatchLikedYouResponse_Removed
+	Change        isWatchLikedYouResponse_Change `protobuf_oneof:"change"`
+	ResumeToken   string                         `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLikedYouResponse) Reset() {
+	*x = WatchLikedYouResponse{}
+	mi := &file_explore_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLikedYouResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLikedYouResponse) ProtoMessage() {}
+
+func (x *WatchLikedYouResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLikedYouResponse.ProtoReflect.Descriptor instead.
+func (*WatchLikedYouResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WatchLikedYouResponse) GetChange() isWatchLikedYouResponse_Change {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *WatchLikedYouResponse) GetLiker() *ListLikedYouResponse_Liker {
+	if x != nil {
+		if x, ok := x.Change.(*WatchLikedYouResponse_Liker); ok {
+			return x.Liker
+		}
+	}
+	return nil
+}
+
+func (x *WatchLikedYouResponse) GetRemoved() *WatchLikedYouResponse_Removal {
+	if x != nil {
+		if x, ok := x.Change.(*WatchLikedYouResponse_Removed); ok {
+			return x.Removed
+		}
+	}
+	return nil
+}
+
+func (x *WatchLikedYouResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type isWatchLikedYouResponse_Change interface {
+	isWatchLikedYouResponse_Change()
+}
+
+type WatchLikedYouResponse_Liker struct {
+	Liker *ListLikedYouResponse_Liker `protobuf:"bytes,1,opt,name=liker,proto3,oneof"`
+}
+
+type WatchLikedYouResponse_Removed struct {
+	Removed *WatchLikedYouResponse_Removal `protobuf:"bytes,2,opt,name=removed,proto3,oneof"`
+}
+
+func (*WatchLikedYouResponse_Liker) isWatchLikedYouResponse_Change() {}
+
+func (*WatchLikedYouResponse_Removed) isWatchLikedYouResponse_Change() {}
+
+type ListLikedYouResponse_Liker struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	SuperLike     bool                   `protobuf:"varint,3,opt,name=super_like,json=superLike,proto3" json:"super_like,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLikedYouResponse_Liker) Reset() {
+	*x = ListLikedYouResponse_Liker{}
+	mi := &file_explore_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedYouResponse_Liker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+
+func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
+func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ListLikedYouResponse_Liker) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *ListLikedYouResponse_Liker) GetSuperLike() bool {
+	if x != nil {
+		return x.SuperLike
+	}
+	return false
+}
+
+type PutDecisionsRequest_Decision struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	DecisionType    DecisionType           `protobuf:"varint,3,opt,name=decision_type,json=decisionType,proto3,enum=explore.DecisionType" json:"decision_type,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PutDecisionsRequest_Decision) Reset() {
+	*x = PutDecisionsRequest_Decision{}
+	mi := &file_explore_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDecisionsRequest_Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsRequest_Decision) ProtoMessage() {}
+
+func (x *PutDecisionsRequest_Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsRequest_Decision.ProtoReflect.Descriptor instead.
+func (*PutDecisionsRequest_Decision) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *PutDecisionsRequest_Decision) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *PutDecisionsRequest_Decision) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *PutDecisionsRequest_Decision) GetDecisionType() DecisionType {
+	if x != nil {
+		return x.DecisionType
+	}
+	return DecisionType_DECISION_TYPE_UNSPECIFIED
+}
+
+type PutDecisionsResponse_Result struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          int32                  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	MutualLikes   bool                   `protobuf:"varint,3,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutDecisionsResponse_Result) Reset() {
+	*x = PutDecisionsResponse_Result{}
+	mi := &file_explore_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDecisionsResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsResponse_Result) ProtoMessage() {}
+
+func (x *PutDecisionsResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsResponse_Result.ProtoReflect.Descriptor instead.
+func (*PutDecisionsResponse_Result) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *PutDecisionsResponse_Result) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *PutDecisionsResponse_Result) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *PutDecisionsResponse_Result) GetMutualLikes() bool {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return false
+}
+
+type ListMatchesResponse_Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse_Match) Reset() {
+	*x = ListMatchesResponse_Match{}
+	mi := &file_explore_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse_Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse_Match) ProtoMessage() {}
+
+func (x *ListMatchesResponse_Match) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse_Match.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse_Match) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *ListMatchesResponse_Match) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMatchesResponse_Match) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type GetDecisionHistoryResponse_DecisionEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LikedRecipient bool                   `protobuf:"varint,1,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	UnixTimestamp  uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	DecisionType   DecisionType           `protobuf:"varint,3,opt,name=decision_type,json=decisionType,proto3,enum=explore.DecisionType" json:"decision_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetDecisionHistoryResponse_DecisionEvent) Reset() {
+	*x = GetDecisionHistoryResponse_DecisionEvent{}
+	mi := &file_explore_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionHistoryResponse_DecisionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionHistoryResponse_DecisionEvent) ProtoMessage() {}
+
+func (x *GetDecisionHistoryResponse_DecisionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionHistoryResponse_DecisionEvent.ProtoReflect.Descriptor instead.
+func (*GetDecisionHistoryResponse_DecisionEvent) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{15, 0}
+}
+
+func (x *GetDecisionHistoryResponse_DecisionEvent) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *GetDecisionHistoryResponse_DecisionEvent) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *GetDecisionHistoryResponse_DecisionEvent) GetDecisionType() DecisionType {
+	if x != nil {
+		return x.DecisionType
+	}
+	return DecisionType_DECISION_TYPE_UNSPECIFIED
+}
+
+type ListMyDecisionsResponse_Decision struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	UnixTimestamp   uint64                 `protobuf:"varint,3,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	DecisionType    DecisionType           `protobuf:"varint,4,opt,name=decision_type,json=decisionType,proto3,enum=explore.DecisionType" json:"decision_type,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMyDecisionsResponse_Decision) Reset() {
+	*x = ListMyDecisionsResponse_Decision{}
+	mi := &file_explore_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyDecisionsResponse_Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyDecisionsResponse_Decision) ProtoMessage() {}
+
+func (x *ListMyDecisionsResponse_Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyDecisionsResponse_Decision.ProtoReflect.Descriptor instead.
+func (*ListMyDecisionsResponse_Decision) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{19, 0}
+}
+
+func (x *ListMyDecisionsResponse_Decision) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ListMyDecisionsResponse_Decision) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *ListMyDecisionsResponse_Decision) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *ListMyDecisionsResponse_Decision) GetDecisionType() DecisionType {
+	if x != nil {
+		return x.DecisionType
+	}
+	return DecisionType_DECISION_TYPE_UNSPECIFIED
+}
+
+type ListBlockedResponse_BlockedUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse_BlockedUser) Reset() {
+	*x = ListBlockedResponse_BlockedUser{}
+	mi := &file_explore_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse_BlockedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse_BlockedUser) ProtoMessage() {}
+
+func (x *ListBlockedResponse_BlockedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse_BlockedUser.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse_BlockedUser) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{27, 0}
+}
+
+func (x *ListBlockedResponse_BlockedUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListBlockedResponse_BlockedUser) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type ExportUserDataResponse_HistoryEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	DecisionType    DecisionType           `protobuf:"varint,3,opt,name=decision_type,json=decisionType,proto3,enum=explore.DecisionType" json:"decision_type,omitempty"`
+	UnixTimestamp   uint64                 `protobuf:"varint,4,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse_HistoryEvent) Reset() {
+	*x = ExportUserDataResponse_HistoryEvent{}
+	mi := &file_explore_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse_HistoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse_HistoryEvent) ProtoMessage() {}
+
+func (x *ExportUserDataResponse_HistoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse_HistoryEvent.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse_HistoryEvent) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{33, 0}
+}
+
+func (x *ExportUserDataResponse_HistoryEvent) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse_HistoryEvent) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *ExportUserDataResponse_HistoryEvent) GetDecisionType() DecisionType {
+	if x != nil {
+		return x.DecisionType
+	}
+	return DecisionType_DECISION_TYPE_UNSPECIFIED
+}
+
+func (x *ExportUserDataResponse_HistoryEvent) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type WatchLikedYouResponse_Removal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLikedYouResponse_Removal) Reset() {
+	*x = WatchLikedYouResponse_Removal{}
+	mi := &file_explore_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLikedYouResponse_Removal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLikedYouResponse_Removal) ProtoMessage() {}
+
+func (x *WatchLikedYouResponse_Removal) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLikedYouResponse_Removal.ProtoReflect.Descriptor instead.
+func (*WatchLikedYouResponse_Removal) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{35, 0}
+}
+
+func (x *WatchLikedYouResponse_Removal) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *WatchLikedYouResponse_Removal) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+var File_explore_service_proto protoreflect.FileDescriptor
+
+const file_explore_service_proto_rawDesc = "" +
+	"\n" +
+	"\x15explore-service.proto\x12\aexplore\"\xfc\x02\n" +
+	"\x13ListLikedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01\x12$\n" +
+	"\x05order\x18\x04 \x01(\x0e2\x0e.explore.OrderR\x05order\x125\n" +
+	"\x14since_unix_timestamp\x18\x05 \x01(\x04H\x02R\x12sinceUnixTimestamp\x88\x01\x01\x125\n" +
+	"\x14until_unix_timestamp\x18\x06 \x01(\x04H\x03R\x12untilUnixTimestamp\x88\x01\x01B\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_sizeB\x17\n" +
+	"\x15_since_unix_timestampB\x17\n" +
+	"\x15_until_unix_timestamp\"\x90\x02\n" +
+	"\x14ListLikedYouResponse\x12;\n" +
+	"\x06likers\x18\x01 \x03(\v2#.explore.ListLikedYouResponse.LikerR\x06likers\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1ah\n" +
+	"\x05Liker\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestamp\x12\x1d\n" +
+	"\n" +
+	"super_like\x18\x03 \x01(\bR\tsuperLikeB\x18\n" +
+	"\x16_next_pagination_token\"\xe2\x01\n" +
+	"\x14CountLikedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x125\n" +
+	"\x14since_unix_timestamp\x18\x02 \x01(\x04H\x00R\x12sinceUnixTimestamp\x88\x01\x01\x125\n" +
+	"\x14until_unix_timestamp\x18\x03 \x01(\x04H\x01R\x12untilUnixTimestamp\x88\x01\x01B\x17\n" +
+	"\x15_since_unix_timestampB\x17\n" +
+	"\x15_until_unix_timestamp\"-\n" +
+	"\x15CountLikedYouResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\"0\n" +
+	"\x15GetLikeSummaryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"e\n" +
+	"\x16GetLikeSummaryResponse\x12\x14\n" +
+	"\x05likes\x18\x01 \x01(\x04R\x05likes\x12\x1b\n" +
+	"\tnew_likes\x18\x02 \x01(\x04R\bnewLikes\x12\x18\n" +
+	"\amatches\x18\x03 \x01(\x04R\amatches\"\x8b\x02\n" +
+	"\x12PutDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\x12,\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01\x12:\n" +
+	"\rdecision_type\x18\x05 \x01(\x0e2\x15.explore.DecisionTypeR\fdecisionTypeB\x12\n" +
+	"\x10_idempotency_key\"8\n" +
+	"\x13PutDecisionResponse\x12!\n" +
+	"\fmutual_likes\x18\x01 \x01(\bR\vmutualLikes\"\x9c\x02\n" +
+	"\x13PutDecisionsRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12C\n" +
+	"\tdecisions\x18\x02 \x03(\v2%.explore.PutDecisionsRequest.DecisionR\tdecisions\x1a\x9b\x01\n" +
+	"\bDecision\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\x12:\n" +
+	"\rdecision_type\x18\x03 \x01(\x0e2\x15.explore.DecisionTypeR\fdecisionType\"\xbc\x01\n" +
+	"\x14PutDecisionsResponse\x12>\n" +
+	"\aresults\x18\x01 \x03(\v2$.explore.PutDecisionsResponse.ResultR\aresults\x1ad\n" +
+	"\x06Result\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12!\n" +
+	"\fmutual_likes\x18\x03 \x01(\bR\vmutualLikes\";\n" +
+	"\x15RewindDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\"\xb6\x02\n" +
+	"\x16RewindDecisionResponse\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\x12=\n" +
+	"\x18restored_liked_recipient\x18\x03 \x01(\bH\x00R\x16restoredLikedRecipient\x88\x01\x01\x12P\n" +
+	"\x16restored_decision_type\x18\x04 \x01(\x0e2\x15.explore.DecisionTypeH\x01R\x14restoredDecisionType\x88\x01\x01B\x1b\n" +
+	"\x19_restored_liked_recipientB\x19\n" +
+	"\x17_restored_decision_type\"r\n" +
+	"\x12ListMatchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01B\x13\n" +
+	"\x11_pagination_token\"\xef\x01\n" +
+	"\x13ListMatchesResponse\x12<\n" +
+	"\amatches\x18\x01 \x03(\v2\".explore.ListMatchesResponse.MatchR\amatches\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aG\n" +
+	"\x05Match\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"k\n" +
+	"\x19GetDecisionHistoryRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\"\x85\x02\n" +
+	"\x1aGetDecisionHistoryResponse\x12I\n" +
+	"\x06events\x18\x01 \x03(\v21.explore.GetDecisionHistoryResponse.DecisionEventR\x06events\x1a\x9b\x01\n" +
+	"\rDecisionEvent\x12'\n" +
+	"\x0fliked_recipient\x18\x01 \x01(\bR\x0elikedRecipient\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestamp\x12:\n" +
+	"\rdecision_type\x18\x03 \x01(\x0e2\x15.explore.DecisionTypeR\fdecisionType\"d\n" +
+	"\x12GetDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\"\xbb\x01\n" +
+	"\x13GetDecisionResponse\x12\x18\n" +
+	"\adecided\x18\x01 \x01(\bR\adecided\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\x12%\n" +
+	"\x0eunix_timestamp\x18\x03 \x01(\x04R\runixTimestamp\x12:\n" +
+	"\rdecision_type\x18\x04 \x01(\x0e2\x15.explore.DecisionTypeR\fdecisionType\"\xe2\x01\n" +
+	"\x16ListMyDecisionsRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12/\n" +
+	"\x06filter\x18\x03 \x01(\x0e2\x17.explore.DecisionFilterR\x06filter\x12 \n" +
+	"\tpage_size\x18\x04 \x01(\rH\x01R\bpageSize\x88\x01\x01B\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\xfa\x02\n" +
+	"\x17ListMyDecisionsResponse\x12G\n" +
+	"\tdecisions\x18\x01 \x03(\v2).explore.ListMyDecisionsResponse.DecisionR\tdecisions\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1a\xc2\x01\n" +
+	"\bDecision\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\x12%\n" +
+	"\x0eunix_timestamp\x18\x03 \x01(\x04R\runixTimestamp\x12:\n" +
+	"\rdecision_type\x18\x04 \x01(\x0e2\x15.explore.DecisionTypeR\fdecisionTypeB\x18\n" +
+	"\x16_next_pagination_token\"a\n" +
+	"\x16FilterUndecidedRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12#\n" +
+	"\rcandidate_ids\x18\x02 \x03(\tR\fcandidateIds\">\n" +
+	"\x17FilterUndecidedResponse\x12#\n" +
+	"\rundecided_ids\x18\x01 \x03(\tR\fundecidedIds\"S\n" +
+	"\x10BlockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\"\x13\n" +
+	"\x11BlockUserResponse\"U\n" +
+	"\x12UnblockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\"\x15\n" +
+	"\x13UnblockUserResponse\"\xa2\x01\n" +
+	"\x12ListBlockedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x03 \x01(\rH\x01R\bpageSize\x88\x01\x01B\x13\n" +
+	"\x11_pagination_tokenB\f\n" +
+	"\n" +
+	"_page_size\"\x86\x02\n" +
+	"\x13ListBlockedResponse\x12M\n" +
+	"\rblocked_users\x18\x01 \x03(\v2(.explore.ListBlockedResponse.BlockedUserR\fblockedUsers\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aM\n" +
+	"\vBlockedUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"K\n" +
+	"\x18SetUserVisibilityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06hidden\x18\x02 \x01(\bR\x06hidden\"\x1b\n" +
+	"\x19SetUserVisibilityResponse\"0\n" +
+	"\x15DeleteUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xcb\x01\n" +
+	"\x16DeleteUserDataResponse\x12\x1c\n" +
+	"\tdecisions\x18\x01 \x01(\x04R\tdecisions\x12'\n" +
+	"\x0fdecision_events\x18\x02 \x01(\x04R\x0edecisionEvents\x12\x18\n" +
+	"\amatches\x18\x03 \x01(\x04R\amatches\x12\x16\n" +
+	"\x06blocks\x18\x04 \x01(\x04R\x06blocks\x128\n" +
+	"\x18completed_unix_timestamp\x18\x05 \x01(\x04R\x16completedUnixTimestamp\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xba\x04\n" +
+	"\x16ExportUserDataResponse\x129\n" +
+	"\asummary\x18\x01 \x01(\v2\x1f.explore.GetLikeSummaryResponseR\asummary\x12G\n" +
+	"\tdecisions\x18\x02 \x03(\v2).explore.ListMyDecisionsResponse.DecisionR\tdecisions\x12F\n" +
+	"\ahistory\x18\x03 \x03(\v2,.explore.ExportUserDataResponse.HistoryEventR\ahistory\x12<\n" +
+	"\amatches\x18\x04 \x03(\v2\".explore.ListMatchesResponse.MatchR\amatches\x12M\n" +
+	"\rblocked_users\x18\x05 \x03(\v2(.explore.ListBlockedResponse.BlockedUserR\fblockedUsers\x1a\xc6\x01\n" +
+	"\fHistoryEvent\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\x12:\n" +
+	"\rdecision_type\x18\x03 \x01(\x0e2\x15.explore.DecisionTypeR\fdecisionType\x12%\n" +
+	"\x0eunix_timestamp\x18\x04 \x01(\x04R\runixTimestamp\"{\n" +
+	"\x14WatchLikedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12&\n" +
+	"\fresume_token\x18\x02 \x01(\tH\x00R\vresumeToken\x88\x01\x01B\x0f\n" +
+	"\r_resume_token\"\x92\x02\n" +
+	"\x15WatchLikedYouResponse\x12;\n" +
+	"\x05liker\x18\x01 \x01(\v2#.explore.ListLikedYouResponse.LikerH\x00R\x05liker\x12B\n" +
+	"\aremoved\x18\x02 \x01(\v2&.explore.WatchLikedYouResponse.RemovalH\x00R\aremoved\x12!\n" +
+	"\fresume_token\x18\x03 \x01(\tR\vresumeToken\x1aK\n" +
+	"\aRemoval\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\b\n" +
+	"\x06change*N\n" +
+	"\x05Order\x12\x15\n" +
+	"\x11ORDER_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ORDER_NEWEST_FIRST\x10\x01\x12\x16\n" +
+	"\x12ORDER_OLDEST_FIRST\x10\x02*{\n" +
+	"\fDecisionType\x12\x1d\n" +
+	"\x19DECISION_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12DECISION_TYPE_PASS\x10\x01\x12\x16\n" +
+	"\x12DECISION_TYPE_LIKE\x10\x02\x12\x1c\n" +
+	"\x18DECISION_TYPE_SUPER_LIKE\x10\x03*`\n" +
+	"\x0eDecisionFilter\x12\x17\n" +
+	"\x13DECISION_FILTER_ALL\x10\x00\x12\x19\n" +
+	"\x15DECISION_FILTER_LIKED\x10\x01\x12\x1a\n" +
+	"\x16DECISION_FILTER_PASSED\x10\x022\xb2\v\n" +
+	"\x0eExploreService\x12K\n" +
+	"\fListLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
+	"\x0fListNewLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
+	"\rCountLikedYou\x12\x1d.explore.CountLikedYouRequest\x1a\x1e.explore.CountLikedYouResponse\x12Q\n" +
+	"\x10CountNewLikedYou\x12\x1d.explore.CountLikedYouRequest\x1a\x1e.explore.CountLikedYouResponse\x12Q\n" +
+	"\x0eGetLikeSummary\x12\x1e.explore.GetLikeSummaryRequest\x1a\x1f.explore.GetLikeSummaryResponse\x12H\n" +
+	"\vPutDecision\x12\x1b.explore.PutDecisionRequest\x1a\x1c.explore.PutDecisionResponse\x12K\n" +
+	"\fPutDecisions\x12\x1c.explore.PutDecisionsRequest\x1a\x1d.explore.PutDecisionsResponse\x12Q\n" +
+	"\x0eRewindDecision\x12\x1e.explore.RewindDecisionRequest\x1a\x1f.explore.RewindDecisionResponse\x12H\n" +
+	"\vListMatches\x12\x1b.explore.ListMatchesRequest\x1a\x1c.explore.ListMatchesResponse\x12]\n" +
+	"\x12GetDecisionHistory\x12\".explore.GetDecisionHistoryRequest\x1a#.explore.GetDecisionHistoryResponse\x12H\n" +
+	"\vGetDecision\x12\x1b.explore.GetDecisionRequest\x1a\x1c.explore.GetDecisionResponse\x12T\n" +
+	"\x0fListMyDecisions\x12\x1f.explore.ListMyDecisionsRequest\x1a .explore.ListMyDecisionsResponse\x12T\n" +
+	"\x0fFilterUndecided\x12\x1f.explore.FilterUndecidedRequest\x1a .explore.FilterUndecidedResponse\x12B\n" +
+	"\tBlockUser\x12\x19.explore.BlockUserRequest\x1a\x1a.explore.BlockUserResponse\x12H\n" +
+	"\vUnblockUser\x12\x1b.explore.UnblockUserRequest\x1a\x1c.explore.UnblockUserResponse\x12H\n" +
+	"\vListBlocked\x12\x1b.explore.ListBlockedRequest\x1a\x1c.explore.ListBlockedResponse\x12Z\n" +
+	"\x11SetUserVisibility\x12!.explore.SetUserVisibilityRequest\x1a\".explore.SetUserVisibilityResponse\x12P\n" +
+	"\rWatchLikedYou\x12\x1d.explore.WatchLikedYouRequest\x1a\x1e.explore.WatchLikedYouResponse0\x012\xbd\x01\n" +
+	"\x13ExploreAdminService\x12Q\n" +
+	"\x0eDeleteUserData\x12\x1e.explore.DeleteUserDataRequest\x1a\x1f.explore.DeleteUserDataResponse\x12S\n" +
+	"\x0eExportUserData\x12\x1e.explore.ExportUserDataRequest\x1a\x1f.explore.ExportUserDataResponse0\x01B!Z\x1fexplore_service/proto;explorepbb\x06proto3"
+
+var (
+	file_explore_service_proto_rawDescOnce sync.Once
+	file_explore_service_proto_rawDescData []byte
+)
+
+func file_explore_service_proto_rawDescGZIP() []byte {
+	file_explore_service_proto_rawDescOnce.Do(func() {
+		file_explore_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_explore_service_proto_rawDesc), len(file_explore_service_proto_rawDesc)))
+	})
+	return file_explore_service_proto_rawDescData
+}
+
+var file_explore_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_explore_service_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_explore_service_proto_goTypes = []any{
+	(Order)(0),                                       // 0: explore.Order
+	(DecisionType)(0),                                // 1: explore.DecisionType
+	(DecisionFilter)(0),                              // 2: explore.DecisionFilter
+	(*ListLikedYouRequest)(nil),                      // 3: explore.ListLikedYouRequest
+	(*ListLikedYouResponse)(nil),                     // 4: explore.ListLikedYouResponse
+	(*CountLikedYouRequest)(nil),                     // 5: explore.CountLikedYouRequest
+	(*CountLikedYouResponse)(nil),                    // 6: explore.CountLikedYouResponse
+	(*GetLikeSummaryRequest)(nil),                    // 7: explore.GetLikeSummaryRequest
+	(*GetLikeSummaryResponse)(nil),                   // 8: explore.GetLikeSummaryResponse
+	(*PutDecisionRequest)(nil),                       // 9: explore.PutDecisionRequest
+	(*PutDecisionResponse)(nil),                      // 10: explore.PutDecisionResponse
+	(*PutDecisionsRequest)(nil),                      // 11: explore.PutDecisionsRequest
+	(*PutDecisionsResponse)(nil),                     // 12: explore.PutDecisionsResponse
+	(*RewindDecisionRequest)(nil),                    // 13: explore.RewindDecisionRequest
+	(*RewindDecisionResponse)(nil),                   // 14: explore.RewindDecisionResponse
+	(*ListMatchesRequest)(nil),                       // 15: explore.ListMatchesRequest
+	(*ListMatchesResponse)(nil),                      // 16: explore.ListMatchesResponse
+	(*GetDecisionHistoryRequest)(nil),                // 17: explore.GetDecisionHistoryRequest
+	(*GetDecisionHistoryResponse)(nil),               // 18: explore.GetDecisionHistoryResponse
+	(*GetDecisionRequest)(nil),                       // 19: explore.GetDecisionRequest
+	(*GetDecisionResponse)(nil),                      // 20: explore.GetDecisionResponse
+	(*ListMyDecisionsRequest)(nil),                   // 21: explore.ListMyDecisionsRequest
+	(*ListMyDecisionsResponse)(nil),                  // 22: explore.ListMyDecisionsResponse
+	(*FilterUndecidedRequest)(nil),                   // 23: explore.FilterUndecidedRequest
+	(*FilterUndecidedResponse)(nil),                  // 24: explore.FilterUndecidedResponse
+	(*BlockUserRequest)(nil),                         // 25: explore.BlockUserRequest
+	(*BlockUserResponse)(nil),                        // 26: explore.BlockUserResponse
+	(*UnblockUserRequest)(nil),                       // 27: explore.UnblockUserRequest
+	(*UnblockUserResponse)(nil),                      // 28: explore.UnblockUserResponse
+	(*ListBlockedRequest)(nil),                       // 29: explore.ListBlockedRequest
+	(*ListBlockedResponse)(nil),                      // 30: explore.ListBlockedResponse
+	(*SetUserVisibilityRequest)(nil),                 // 31: explore.SetUserVisibilityRequest
+	(*SetUserVisibilityResponse)(nil),                // 32: explore.SetUserVisibilityResponse
+	(*DeleteUserDataRequest)(nil),                    // 33: explore.DeleteUserDataRequest
+	(*DeleteUserDataResponse)(nil),                   // 34: explore.DeleteUserDataResponse
+	(*ExportUserDataRequest)(nil),                    // 35: explore.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),                   // 36: explore.ExportUserDataResponse
+	(*WatchLikedYouRequest)(nil),                     // 37: explore.WatchLikedYouRequest
+	(*WatchLikedYouResponse)(nil),                    // 38: explore.WatchLikedYouResponse
+	(*ListLikedYouResponse_Liker)(nil),               // 39: explore.ListLikedYouResponse.Liker
+	(*PutDecisionsRequest_Decision)(nil),             // 40: explore.PutDecisionsRequest.Decision
+	(*PutDecisionsResponse_Result)(nil),              // 41: explore.PutDecisionsResponse.Result
+	(*ListMatchesResponse_Match)(nil),                // 42: explore.ListMatchesResponse.Match
+	(*GetDecisionHistoryResponse_DecisionEvent)(nil), // 43: explore.GetDecisionHistoryResponse.DecisionEvent
+	(*ListMyDecisionsResponse_Decision)(nil),         // 44: explore.ListMyDecisionsResponse.Decision
+	(*ListBlockedResponse_BlockedUser)(nil),          // 45: explore.ListBlockedResponse.BlockedUser
+	(*ExportUserDataResponse_HistoryEvent)(nil),      // 46: explore.ExportUserDataResponse.HistoryEvent
+	(*WatchLikedYouResponse_Removal)(nil),            // 47: explore.WatchLikedYouResponse.Removal
+}
+var file_explore_service_proto_depIdxs = []int32{
+	0,  // 0: explore.ListLikedYouRequest.order:type_name -> explore.Order
+	39, // 1: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
+	1,  // 2: explore.PutDecisionRequest.decision_type:type_name -> explore.DecisionType
+	40, // 3: explore.PutDecisionsRequest.decisions:type_name -> explore.PutDecisionsRequest.Decision
+	41, // 4: explore.PutDecisionsResponse.results:type_name -> explore.PutDecisionsResponse.Result
+	1,  // 5: explore.RewindDecisionResponse.restored_decision_type:type_name -> explore.DecisionType
+	42, // 6: explore.ListMatchesResponse.matches:type_name -> explore.ListMatchesResponse.Match
+	43, // 7: explore.GetDecisionHistoryResponse.events:type_name -> explore.GetDecisionHistoryResponse.DecisionEvent
+	1,  // 8: explore.GetDecisionResponse.decision_type:type_name -> explore.DecisionType
+	2,  // 9: explore.ListMyDecisionsRequest.filter:type_name -> explore.DecisionFilter
+	44, // 10: explore.ListMyDecisionsResponse.decisions:type_name -> explore.ListMyDecisionsResponse.Decision
+	45, // 11: explore.ListBlockedResponse.blocked_users:type_name -> explore.ListBlockedResponse.BlockedUser
+	8,  // 12: explore.ExportUserDataResponse.summary:type_name -> explore.GetLikeSummaryResponse
+	44, // 13: explore.ExportUserDataResponse.decisions:type_name -> explore.ListMyDecisionsResponse.Decision
+	46, // 14: explore.ExportUserDataResponse.history:type_name -> explore.ExportUserDataResponse.HistoryEvent
+	42, // 15: explore.ExportUserDataResponse.matches:type_name -> explore.ListMatchesResponse.Match
+	45, // 16: explore.ExportUserDataResponse.blocked_users:type_name -> explore.ListBlockedResponse.BlockedUser
+	39, // 17: explore.WatchLikedYouResponse.liker:type_name -> explore.ListLikedYouResponse.Liker
+	47, // 18: explore.WatchLikedYouResponse.removed:type_name -> explore.WatchLikedYouResponse.Removal
+	1,  // 19: explore.PutDecisionsRequest.Decision.decision_type:type_name -> explore.DecisionType
+	1,  // 20: explore.GetDecisionHistoryResponse.DecisionEvent.decision_type:type_name -> explore.DecisionType
+	1,  // 21: explore.ListMyDecisionsResponse.Decision.decision_type:type_name -> explore.DecisionType
+	1,  // 22: explore.ExportUserDataResponse.HistoryEvent.decision_type:type_name -> explore.DecisionType
+	3,  // 23: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
+	3,  // 24: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
+	5,  // 25: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
+	5,  // 26: explore.ExploreService.CountNewLikedYou:input_type -> explore.CountLikedYouRequest
+	7,  // 27: explore.ExploreService.GetLikeSummary:input_type -> explore.GetLikeSummaryRequest
+	9,  // 28: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
+	11, // 29: explore.ExploreService.PutDecisions:input_type -> explore.PutDecisionsRequest
+	13, // 30: explore.ExploreService.RewindDecision:input_type -> explore.RewindDecisionRequest
+	15, // 31: explore.ExploreService.ListMatches:input_type -> explore.ListMatchesRequest
+	17, // 32: explore.ExploreService.GetDecisionHistory:input_type -> explore.GetDecisionHistoryRequest
+	19, // 33: explore.ExploreService.GetDecision:input_type -> explore.GetDecisionRequest
+	21, // 34: explore.ExploreService.ListMyDecisions:input_type -> explore.ListMyDecisionsRequest
+	23, // 35: explore.ExploreService.FilterUndecided:input_type -> explore.FilterUndecidedRequest
+	25, // 36: explore.ExploreService.BlockUser:input_type -> explore.BlockUserRequest
+	27, // 37: explore.ExploreService.UnblockUser:input_type -> explore.UnblockUserRequest
+	29, // 38: explore.ExploreService.ListBlocked:input_type -> explore.ListBlockedRequest
+	31, // 39: explore.ExploreService.SetUserVisibility:input_type -> explore.SetUserVisibilityRequest
+	37, // 40: explore.ExploreService.WatchLikedYou:input_type -> explore.WatchLikedYouRequest
+	33, // 41: explore.ExploreAdminService.DeleteUserData:input_type -> explore.DeleteUserDataRequest
+	35, // 42: explore.ExploreAdminService.ExportUserData:input_type -> explore.ExportUserDataRequest
+	4,  // 43: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
+	4,  // 44: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
+	6,  // 45: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
+	6,  // 46: explore.ExploreService.CountNewLikedYou:output_type -> explore.CountLikedYouResponse
+	8,  // 47: explore.ExploreService.GetLikeSummary:output_type -> explore.GetLikeSummaryResponse
+	10, // 48: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
+	12, // 49: explore.ExploreService.PutDecisions:output_type -> explore.PutDecisionsResponse
+	14, // 50: explore.ExploreService.RewindDecision:output_type -> explore.RewindDecisionResponse
+	16, // 51: explore.ExploreService.ListMatches:output_type -> explore.ListMatchesResponse
+	18, // 52: explore.ExploreService.GetDecisionHistory:output_type -> explore.GetDecisionHistoryResponse
+	20, // 53: explore.ExploreService.GetDecision:output_type -> explore.GetDecisionResponse
+	22, // 54: explore.ExploreService.ListMyDecisions:output_type -> explore.ListMyDecisionsResponse
+	24, // 55: explore.ExploreService.FilterUndecided:output_type -> explore.FilterUndecidedResponse
+	26, // 56: explore.ExploreService.BlockUser:output_type -> explore.BlockUserResponse
+	28, // 57: explore.ExploreService.UnblockUser:output_type -> explore.UnblockUserResponse
+	30, // 58: explore.ExploreService.ListBlocked:output_type -> explore.ListBlockedResponse
+	32, // 59: explore.ExploreService.SetUserVisibility:output_type -> explore.SetUserVisibilityResponse
+	38, // 60: explore.ExploreService.WatchLikedYou:output_type -> explore.WatchLikedYouResponse
+	34, // 61: explore.ExploreAdminService.DeleteUserData:output_type -> explore.DeleteUserDataResponse
+	36, // 62: explore.ExploreAdminService.ExportUserData:output_type -> explore.ExportUserDataResponse
+	43, // [43:63] is the sub-list for method output_type
+	23, // [23:43] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_explore_service_proto_init() }
+func file_explore_service_proto_init() {
+	if File_explore_service_proto != nil {
+		return
 	}
-	return interceptor(ctx, in, info, handler)
+	file_explore_service_proto_msgTypes[0].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[1].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[2].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[11].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[12].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[13].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[18].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[19].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[26].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[27].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[34].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[35].OneofWrappers = []any{
+		(*WatchLikedYouResponse_Liker)(nil),
+		(*WatchLikedYouResponse_Removed)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_explore_service_proto_rawDesc), len(file_explore_service_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   45,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_explore_service_proto_goTypes,
+		DependencyIndexes: file_explore_service_proto_depIdxs,
+		EnumInfos:         file_explore_service_proto_enumTypes,
+		MessageInfos:      file_explore_service_proto_msgTypes,
+	}.Build()
+	File_explore_service_proto = out.File
+	file_explore_service_proto_goTypes = nil
+	file_explore_service_proto_depIdxs = nil
 }
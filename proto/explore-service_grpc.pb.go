@@ -0,0 +1,1069 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: explore-service.proto
+
+package explorepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExploreService_ListLikedYou_FullMethodName       = "/explore.ExploreService/ListLikedYou"
+	ExploreService_ListNewLikedYou_FullMethodName    = "/explore.ExploreService/ListNewLikedYou"
+	ExploreService_CountLikedYou_FullMethodName      = "/explore.ExploreService/CountLikedYou"
+	ExploreService_CountNewLikedYou_FullMethodName   = "/explore.ExploreService/CountNewLikedYou"
+	ExploreService_GetLikeSummary_FullMethodName     = "/explore.ExploreService/GetLikeSummary"
+	ExploreService_PutDecision_FullMethodName        = "/explore.ExploreService/PutDecision"
+	ExploreService_PutDecisions_FullMethodName       = "/explore.ExploreService/PutDecisions"
+	ExploreService_RewindDecision_FullMethodName     = "/explore.ExploreService/RewindDecision"
+	ExploreService_ListMatches_FullMethodName        = "/explore.ExploreService/ListMatches"
+	ExploreService_GetDecisionHistory_FullMethodName = "/explore.ExploreService/GetDecisionHistory"
+	ExploreService_GetDecision_FullMethodName        = "/explore.ExploreService/GetDecision"
+	ExploreService_ListMyDecisions_FullMethodName    = "/explore.ExploreService/ListMyDecisions"
+	ExploreService_FilterUndecided_FullMethodName    = "/explore.ExploreService/FilterUndecided"
+	ExploreService_BlockUser_FullMethodName          = "/explore.ExploreService/BlockUser"
+	ExploreService_UnblockUser_FullMethodName        = "/explore.ExploreService/UnblockUser"
+	ExploreService_ListBlocked_FullMethodName        = "/explore.ExploreService/ListBlocked"
+	ExploreService_SetUserVisibility_FullMethodName  = "/explore.ExploreService/SetUserVisibility"
+	ExploreService_WatchLikedYou_FullMethodName      = "/explore.ExploreService/WatchLikedYou"
+)
+
+// ExploreServiceClient is the client API for ExploreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExploreServiceClient interface {
+	ListLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
+	// ListNewLikedYou returns all actors who have liked the recipient
+	// except those who have been liked in return.  This allows a
+	// recipient to see only new admirers they haven't reciprocated.
+	ListNewLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
+	// CountLikedYou returns the total number of actors who have liked
+	// the recipient.
+	CountLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error)
+	// CountNewLikedYou returns the number of actors ListNewLikedYou
+	// would return: those who like the recipient and have not been
+	// liked back.
+	CountNewLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error)
+	// GetLikeSummary returns the user's total likes, new likes and
+	// matches in a single round trip.
+	GetLikeSummary(ctx context.Context, in *GetLikeSummaryRequest, opts ...grpc.CallOption) (*GetLikeSummaryResponse, error)
+	// PutDecision records the actor's decision (pass, like or super
+	// like) of another user.  If a decision already exists for this
+	// actor/recipient combination it should be overwritten.  The
+	// response includes a boolean indicating whether the like is mutual.
+	// A super like beyond the actor's daily quota fails with
+	// RESOURCE_EXHAUSTED.
+	PutDecision(ctx context.Context, in *PutDecisionRequest, opts ...grpc.CallOption) (*PutDecisionResponse, error)
+	// PutDecisions records up to the server's batch limit of decisions by
+	// one actor, in order and in a single transaction, for clients that
+	// upload a queue of swipes at once.  Each decision has its own
+	// result: one that is invalid or fails to be stored is skipped and
+	// reported there while the others are still recorded.  If the call
+	// itself fails, none of the decisions were recorded and the whole
+	// batch may be retried.
+	PutDecisions(ctx context.Context, in *PutDecisionsRequest, opts ...grpc.CallOption) (*PutDecisionsResponse, error)
+	// RewindDecision undoes the actor's latest decision, if it was made
+	// within the server's rewind window.  The actor's previous decision
+	// on the same recipient is restored, or the decision is removed if
+	// there was none, and a match that no longer holds is removed.
+	// Calling it again undoes the decision before.  It fails with
+	// FAILED_PRECONDITION when there is nothing to undo.
+	RewindDecision(ctx context.Context, in *RewindDecisionRequest, opts ...grpc.CallOption) (*RewindDecisionResponse, error)
+	// ListMatches returns the users the given user has a mutual like
+	// with, most recent match first.  A match is created when a like
+	// becomes mutual and removed when either user passes.
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
+	// GetDecisionHistory returns every like and pass the actor has
+	// recorded for the recipient, oldest first.  Unlike PutDecision,
+	// which overwrites the current decision, the history is append-only.
+	GetDecisionHistory(ctx context.Context, in *GetDecisionHistoryRequest, opts ...grpc.CallOption) (*GetDecisionHistoryResponse, error)
+	// GetDecision returns the actor's current decision on the recipient,
+	// so clients can tell whether they already liked or passed someone.
+	GetDecision(ctx context.Context, in *GetDecisionRequest, opts ...grpc.CallOption) (*GetDecisionResponse, error)
+	// ListMyDecisions returns the actor's current decisions, most
+	// recently updated first, optionally only likes or only passes.
+	// Clients use it to rebuild their own swipe history.
+	ListMyDecisions(ctx context.Context, in *ListMyDecisionsRequest, opts ...grpc.CallOption) (*ListMyDecisionsResponse, error)
+	// FilterUndecided returns the candidates the actor has not liked or
+	// passed, for recommenders that drop already seen users.  Thousands
+	// of candidates may be checked in one call, up to the server's limit.
+	// If the server expires passes, a pass older than the pass TTL counts
+	// as undecided.
+	FilterUndecided(ctx context.Context, in *FilterUndecidedRequest, opts ...grpc.CallOption) (*FilterUndecidedResponse, error)
+	// BlockUser blocks a user.  Until the block is lifted the two users
+	// do not appear in each other's liked-you listings, counts or
+	// FilterUndecided results and cannot match; an existing match is
+	// removed.  Blocking a user again has no effect.
+	BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error)
+	// UnblockUser lifts a block.  Likes between the two users become
+	// visible again and a mutual like gets a new match, unless the other
+	// user blocked them too.
+	UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error)
+	// ListBlocked returns the users the given user has blocked, most
+	// recent block first.
+	ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*ListBlockedResponse, error)
+	// SetUserVisibility hides or shows a user, for example while their
+	// account is paused.  A hidden user's likes do not appear in other
+	// users' liked-you listings, counts or WatchLikedYou streams until
+	// they are shown again.  Their decisions and matches are kept.
+	SetUserVisibility(ctx context.Context, in *SetUserVisibilityRequest, opts ...grpc.CallOption) (*SetUserVisibilityResponse, error)
+	// WatchLikedYou streams changes to the actors who like the
+	// recipient: a liker whenever someone likes the recipient and a
+	// removal whenever a pass withdraws a like.  Every message carries a
+	// resume_token; pass the last one received to a new call to continue
+	// after a disconnect without missing changes.
+	WatchLikedYou(ctx context.Context, in *WatchLikedYouRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchLikedYouResponse], error)
+}
+
+type exploreServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExploreServiceClient(cc grpc.ClientConnInterface) ExploreServiceClient {
+	return &exploreServiceClient{cc}
+}
+
+func (c *exploreServiceClient) ListLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikedYouResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListLikedYou_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListNewLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikedYouResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListNewLikedYou_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) CountLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountLikedYouResponse)
+	err := c.cc.Invoke(ctx, ExploreService_CountLikedYou_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) CountNewLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountLikedYouResponse)
+	err := c.cc.Invoke(ctx, ExploreService_CountNewLikedYou_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) GetLikeSummary(ctx context.Context, in *GetLikeSummaryRequest, opts ...grpc.CallOption) (*GetLikeSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLikeSummaryResponse)
+	err := c.cc.Invoke(ctx, ExploreService_GetLikeSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) PutDecision(ctx context.Context, in *PutDecisionRequest, opts ...grpc.CallOption) (*PutDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutDecisionResponse)
+	err := c.cc.Invoke(ctx, ExploreService_PutDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) PutDecisions(ctx context.Context, in *PutDecisionsRequest, opts ...grpc.CallOption) (*PutDecisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutDecisionsResponse)
+	err := c.cc.Invoke(ctx, ExploreService_PutDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) RewindDecision(ctx context.Context, in *RewindDecisionRequest, opts ...grpc.CallOption) (*RewindDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RewindDecisionResponse)
+	err := c.cc.Invoke(ctx, ExploreService_RewindDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMatchesResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) GetDecisionHistory(ctx context.Context, in *GetDecisionHistoryRequest, opts ...grpc.CallOption) (*GetDecisionHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDecisionHistoryResponse)
+	err := c.cc.Invoke(ctx, ExploreService_GetDecisionHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) GetDecision(ctx context.Context, in *GetDecisionRequest, opts ...grpc.CallOption) (*GetDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDecisionResponse)
+	err := c.cc.Invoke(ctx, ExploreService_GetDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListMyDecisions(ctx context.Context, in *ListMyDecisionsRequest, opts ...grpc.CallOption) (*ListMyDecisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyDecisionsResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListMyDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) FilterUndecided(ctx context.Context, in *FilterUndecidedRequest, opts ...grpc.CallOption) (*FilterUndecidedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FilterUndecidedResponse)
+	err := c.cc.Invoke(ctx, ExploreService_FilterUndecided_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_UnblockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*ListBlockedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlockedResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) SetUserVisibility(ctx context.Context, in *SetUserVisibilityRequest, opts ...grpc.CallOption) (*SetUserVisibilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserVisibilityResponse)
+	err := c.cc.Invoke(ctx, ExploreService_SetUserVisibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) WatchLikedYou(ctx context.Context, in *WatchLikedYouRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchLikedYouResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExploreService_ServiceDesc.Streams[0], ExploreService_WatchLikedYou_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLikedYouRequest, WatchLikedYouResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_WatchLikedYouClient = grpc.ServerStreamingClient[WatchLikedYouResponse]
+
+// ExploreServiceServer is the server API for ExploreService service.
+// All implementations must embed UnimplementedExploreServiceServer
+// for forward compatibility.
+type ExploreServiceServer interface {
+	ListLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
+	// ListNewLikedYou returns all actors who have liked the recipient
+	// except those who have been liked in return.  This allows a
+	// recipient to see only new admirers they haven't reciprocated.
+	ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
+	// CountLikedYou returns the total number of actors who have liked
+	// the recipient.
+	CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error)
+	// CountNewLikedYou returns the number of actors ListNewLikedYou
+	// would return: those who like the recipient and have not been
+	// liked back.
+	CountNewLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error)
+	// GetLikeSummary returns the user's total likes, new likes and
+	// matches in a single round trip.
+	GetLikeSummary(context.Context, *GetLikeSummaryRequest) (*GetLikeSummaryResponse, error)
+	// PutDecision records the actor's decision (pass, like or super
+	// like) of another user.  If a decision already exists for this
+	// actor/recipient combination it should be overwritten.  The
+	// response includes a boolean indicating whether the like is mutual.
+	// A super like beyond the actor's daily quota fails with
+	// RESOURCE_EXHAUSTED.
+	PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error)
+	// PutDecisions records up to the server's batch limit of decisions by
+	// one actor, in order and in a single transaction, for clients that
+	// upload a queue of swipes at once.  Each decision has its own
+	// result: one that is invalid or fails to be stored is skipped and
+	// reported there while the others are still recorded.  If the call
+	// itself fails, none of the decisions were recorded and the whole
+	// batch may be retried.
+	PutDecisions(context.Context, *PutDecisionsRequest) (*PutDecisionsResponse, error)
+	// RewindDecision undoes the actor's latest decision, if it was made
+	// within the server's rewind window.  The actor's previous decision
+	// on the same recipient is restored, or the decision is removed if
+	// there was none, and a match that no longer holds is removed.
+	// Calling it again undoes the decision before.  It fails with
+	// FAILED_PRECONDITION when there is nothing to undo.
+	RewindDecision(context.Context, *RewindDecisionRequest) (*RewindDecisionResponse, error)
+	// ListMatches returns the users the given user has a mutual like
+	// with, most recent match first.  A match is created when a like
+	// becomes mutual and removed when either user passes.
+	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
+	// GetDecisionHistory returns every like and pass the actor has
+	// recorded for the recipient, oldest first.  Unlike PutDecision,
+	// which overwrites the current decision, the history is append-only.
+	GetDecisionHistory(context.Context, *GetDecisionHistoryRequest) (*GetDecisionHistoryResponse, error)
+	// GetDecision returns the actor's current decision on the recipient,
+	// so clients can tell whether they already liked or passed someone.
+	GetDecision(context.Context, *GetDecisionRequest) (*GetDecisionResponse, error)
+	// ListMyDecisions returns the actor's current decisions, most
+	// recently updated first, optionally only likes or only passes.
+	// Clients use it to rebuild their own swipe history.
+	ListMyDecisions(context.Context, *ListMyDecisionsRequest) (*ListMyDecisionsResponse, error)
+	// FilterUndecided returns the candidates the actor has not liked or
+	// passed, for recommenders that drop already seen users.  Thousands
+	// of candidates may be checked in one call, up to the server's limit.
+	// If the server expires passes, a pass older than the pass TTL counts
+	// as undecided.
+	FilterUndecided(context.Context, *FilterUndecidedRequest) (*FilterUndecidedResponse, error)
+	// BlockUser blocks a user.  Until the block is lifted the two users
+	// do not appear in each other's liked-you listings, counts or
+	// FilterUndecided results and cannot match; an existing match is
+	// removed.  Blocking a user again has no effect.
+	BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error)
+	// UnblockUser lifts a block.  Likes between the two users become
+	// visible again and a mutual like gets a new match, unless the other
+	// user blocked them too.
+	UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error)
+	// ListBlocked returns the users the given user has blocked, most
+	// recent block first.
+	ListBlocked(context.Context, *ListBlockedRequest) (*ListBlockedResponse, error)
+	// SetUserVisibility hides or shows a user, for example while their
+	// account is paused.  A hidden user's likes do not appear in other
+	// users' liked-you listings, counts or WatchLikedYou streams until
+	// they are shown again.  Their decisions and matches are kept.
+	SetUserVisibility(context.Context, *SetUserVisibilityRequest) (*SetUserVisibilityResponse, error)
+	// WatchLikedYou streams changes to the actors who like the
+	// recipient: a liker whenever someone likes the recipient and a
+	// removal whenever a pass withdraws a like.  Every message carries a
+	// resume_token; pass the last one received to a new call to continue
+	// after a disconnect without missing changes.
+	WatchLikedYou(*WatchLikedYouRequest, grpc.ServerStreamingServer[WatchLikedYouResponse]) error
+	mustEmbedUnimplementedExploreServiceServer()
+}
+
+// UnimplementedExploreServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExploreServiceServer struct{}
+
+func (UnimplementedExploreServiceServer) ListLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLikedYou not implemented")
+}
+func (UnimplementedExploreServiceServer) ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNewLikedYou not implemented")
+}
+func (UnimplementedExploreServiceServer) CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountLikedYou not implemented")
+}
+func (UnimplementedExploreServiceServer) CountNewLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountNewLikedYou not implemented")
+}
+func (UnimplementedExploreServiceServer) GetLikeSummary(context.Context, *GetLikeSummaryRequest) (*GetLikeSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLikeSummary not implemented")
+}
+func (UnimplementedExploreServiceServer) PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutDecision not implemented")
+}
+func (UnimplementedExploreServiceServer) PutDecisions(context.Context, *PutDecisionsRequest) (*PutDecisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutDecisions not implemented")
+}
+func (UnimplementedExploreServiceServer) RewindDecision(context.Context, *RewindDecisionRequest) (*RewindDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RewindDecision not implemented")
+}
+func (UnimplementedExploreServiceServer) ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (UnimplementedExploreServiceServer) GetDecisionHistory(context.Context, *GetDecisionHistoryRequest) (*GetDecisionHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDecisionHistory not implemented")
+}
+func (UnimplementedExploreServiceServer) GetDecision(context.Context, *GetDecisionRequest) (*GetDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDecision not implemented")
+}
+func (UnimplementedExploreServiceServer) ListMyDecisions(context.Context, *ListMyDecisionsRequest) (*ListMyDecisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyDecisions not implemented")
+}
+func (UnimplementedExploreServiceServer) FilterUndecided(context.Context, *FilterUndecidedRequest) (*FilterUndecidedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FilterUndecided not implemented")
+}
+func (UnimplementedExploreServiceServer) BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedExploreServiceServer) UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedExploreServiceServer) ListBlocked(context.Context, *ListBlockedRequest) (*ListBlockedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocked not implemented")
+}
+func (UnimplementedExploreServiceServer) SetUserVisibility(context.Context, *SetUserVisibilityRequest) (*SetUserVisibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserVisibility not implemented")
+}
+func (UnimplementedExploreServiceServer) WatchLikedYou(*WatchLikedYouRequest, grpc.ServerStreamingServer[WatchLikedYouResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLikedYou not implemented")
+}
+func (UnimplementedExploreServiceServer) mustEmbedUnimplementedExploreServiceServer() {}
+func (UnimplementedExploreServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeExploreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExploreServiceServer will
+// result in compilation errors.
+type UnsafeExploreServiceServer interface {
+	mustEmbedUnimplementedExploreServiceServer()
+}
+
+func RegisterExploreServiceServer(s grpc.ServiceRegistrar, srv ExploreServiceServer) {
+	// If the following call pancis, it indicates UnimplementedExploreServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExploreService_ServiceDesc, srv)
+}
+
+func _ExploreService_ListLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikedYouRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListLikedYou(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListLikedYou_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListLikedYou(ctx, req.(*ListLikedYouRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListNewLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikedYouRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListNewLikedYou(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListNewLikedYou_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListNewLikedYou(ctx, req.(*ListLikedYouRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_CountLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountLikedYouRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).CountLikedYou(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_CountLikedYou_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).CountLikedYou(ctx, req.(*CountLikedYouRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_CountNewLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountLikedYouRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).CountNewLikedYou(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_CountNewLikedYou_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).CountNewLikedYou(ctx, req.(*CountLikedYouRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_GetLikeSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLikeSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).GetLikeSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_GetLikeSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).GetLikeSummary(ctx, req.(*GetLikeSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_PutDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).PutDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_PutDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).PutDecision(ctx, req.(*PutDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_PutDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).PutDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_PutDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).PutDecisions(ctx, req.(*PutDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_RewindDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewindDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).RewindDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_RewindDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).RewindDecision(ctx, req.(*RewindDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListMatches(ctx, req.(*ListMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_GetDecisionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecisionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).GetDecisionHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_GetDecisionHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).GetDecisionHistory(ctx, req.(*GetDecisionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_GetDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).GetDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_GetDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).GetDecision(ctx, req.(*GetDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListMyDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListMyDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListMyDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListMyDecisions(ctx, req.(*ListMyDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_FilterUndecided_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterUndecidedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).FilterUndecided(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_FilterUndecided_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).FilterUndecided(ctx, req.(*FilterUndecidedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).BlockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).UnblockUser(ctx, req.(*UnblockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListBlocked(ctx, req.(*ListBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_SetUserVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).SetUserVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_SetUserVisibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).SetUserVisibility(ctx, req.(*SetUserVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_WatchLikedYou_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLikedYouRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExploreServiceServer).WatchLikedYou(m, &grpc.GenericServerStream[WatchLikedYouRequest, WatchLikedYouResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_WatchLikedYouServer = grpc.ServerStreamingServer[WatchLikedYouResponse]
+
+// ExploreService_ServiceDesc is the grpc.ServiceDesc for ExploreService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExploreService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "explore.ExploreService",
+	HandlerType: (*ExploreServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListLikedYou",
+			Handler:    _ExploreService_ListLikedYou_Handler,
+		},
+		{
+			MethodName: "ListNewLikedYou",
+			Handler:    _ExploreService_ListNewLikedYou_Handler,
+		},
+		{
+			MethodName: "CountLikedYou",
+			Handler:    _ExploreService_CountLikedYou_Handler,
+		},
+		{
+			MethodName: "CountNewLikedYou",
+			Handler:    _ExploreService_CountNewLikedYou_Handler,
+		},
+		{
+			MethodName: "GetLikeSummary",
+			Handler:    _ExploreService_GetLikeSummary_Handler,
+		},
+		{
+			MethodName: "PutDecision",
+			Handler:    _ExploreService_PutDecision_Handler,
+		},
+		{
+			MethodName: "PutDecisions",
+			Handler:    _ExploreService_PutDecisions_Handler,
+		},
+		{
+			MethodName: "RewindDecision",
+			Handler:    _ExploreService_RewindDecision_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _ExploreService_ListMatches_Handler,
+		},
+		{
+			MethodName: "GetDecisionHistory",
+			Handler:    _ExploreService_GetDecisionHistory_Handler,
+		},
+		{
+			MethodName: "GetDecision",
+			Handler:    _ExploreService_GetDecision_Handler,
+		},
+		{
+			MethodName: "ListMyDecisions",
+			Handler:    _ExploreService_ListMyDecisions_Handler,
+		},
+		{
+			MethodName: "FilterUndecided",
+			Handler:    _ExploreService_FilterUndecided_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _ExploreService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _ExploreService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "ListBlocked",
+			Handler:    _ExploreService_ListBlocked_Handler,
+		},
+		{
+			MethodName: "SetUserVisibility",
+			Handler:    _ExploreService_SetUserVisibility_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLikedYou",
+			Handler:       _ExploreService_WatchLikedYou_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "explore-service.proto",
+}
+
+const (
+	ExploreAdminService_DeleteUserData_FullMethodName = "/explore.ExploreAdminService/DeleteUserData"
+	ExploreAdminService_ExportUserData_FullMethodName = "/explore.ExploreAdminService/ExportUserData"
+)
+
+// ExploreAdminServiceClient is the client API for ExploreAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ExploreAdminService holds operator RPCs that act on all of a user's
+// data.  It is only served when ADMIN_RPCS is enabled and must not be
+// reachable by end users.
+type ExploreAdminServiceClient interface {
+	// DeleteUserData erases everything stored about a user, for account
+	// deletion: their decisions and the decisions on them with their
+	// history, matches, blocks and counts.  Other users' counts are
+	// corrected as if the user had withdrawn their likes.  Rows are
+	// deleted in small batches so that the erasure does not hold up
+	// other requests, and it is recorded in the erasure log once
+	// complete.  Repeating the call is harmless.
+	DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error)
+	// ExportUserData streams everything stored about a user, for data
+	// access requests.  The first message holds the summary; every later
+	// message holds one batch of decisions, history, matches or blocks.
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUserDataResponse], error)
+}
+
+type exploreAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExploreAdminServiceClient(cc grpc.ClientConnInterface) ExploreAdminServiceClient {
+	return &exploreAdminServiceClient{cc}
+}
+
+func (c *exploreAdminServiceClient) DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserDataResponse)
+	err := c.cc.Invoke(ctx, ExploreAdminService_DeleteUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreAdminServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUserDataResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExploreAdminService_ServiceDesc.Streams[0], ExploreAdminService_ExportUserData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUserDataRequest, ExportUserDataResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreAdminService_ExportUserDataClient = grpc.ServerStreamingClient[ExportUserDataResponse]
+
+// ExploreAdminServiceServer is the server API for ExploreAdminService service.
+// All implementations must embed UnimplementedExploreAdminServiceServer
+// for forward compatibility.
+//
+// ExploreAdminService holds operator RPCs that act on all of a user's
+// data.  It is only served when ADMIN_RPCS is enabled and must not be
+// reachable by end users.
+type ExploreAdminServiceServer interface {
+	// DeleteUserData erases everything stored about a user, for account
+	// deletion: their decisions and the decisions on them with their
+	// history, matches, blocks and counts.  Other users' counts are
+	// corrected as if the user had withdrawn their likes.  Rows are
+	// deleted in small batches so that the erasure does not hold up
+	// other requests, and it is recorded in the erasure log once
+	// complete.  Repeating the call is harmless.
+	DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error)
+	// ExportUserData streams everything stored about a user, for data
+	// access requests.  The first message holds the summary; every later
+	// message holds one batch of decisions, history, matches or blocks.
+	ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportUserDataResponse]) error
+	mustEmbedUnimplementedExploreAdminServiceServer()
+}
+
+// UnimplementedExploreAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExploreAdminServiceServer struct{}
+
+func (UnimplementedExploreAdminServiceServer) DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserData not implemented")
+}
+func (UnimplementedExploreAdminServiceServer) ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportUserDataResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedExploreAdminServiceServer) mustEmbedUnimplementedExploreAdminServiceServer() {}
+func (UnimplementedExploreAdminServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeExploreAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExploreAdminServiceServer will
+// result in compilation errors.
+type UnsafeExploreAdminServiceServer interface {
+	mustEmbedUnimplementedExploreAdminServiceServer()
+}
+
+func RegisterExploreAdminServiceServer(s grpc.ServiceRegistrar, srv ExploreAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedExploreAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExploreAdminService_ServiceDesc, srv)
+}
+
+func _ExploreAdminService_DeleteUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreAdminServiceServer).DeleteUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreAdminService_DeleteUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreAdminServiceServer).DeleteUserData(ctx, req.(*DeleteUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreAdminService_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExploreAdminServiceServer).ExportUserData(m, &grpc.GenericServerStream[ExportUserDataRequest, ExportUserDataResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreAdminService_ExportUserDataServer = grpc.ServerStreamingServer[ExportUserDataResponse]
+
+// ExploreAdminService_ServiceDesc is the grpc.ServiceDesc for ExploreAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExploreAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "explore.ExploreAdminService",
+	HandlerType: (*ExploreAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeleteUserData",
+			Handler:    _ExploreAdminService_DeleteUserData_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _ExploreAdminService_ExportUserData_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "explore-service.proto",
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"explore_service/internal/server"
+	"explore_service/internal/storage"
+	explorepb "explore_service/proto"
+)
+
+// TestAdminServer checks that ExportUserData streams a user's data in
+// batches and that DeleteUserData erases it.
+func TestAdminServer(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewMemoryStore()
+	srv := server.NewExploreServer(store, 10)
+	admin := server.NewAdminServer(store, 2)
+	for _, recipient := range []string{"user1", "user2", "user3"} {
+		if _, err := srv.PutDecision(ctx, &explorepb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: recipient, LikedRecipient: true}); err != nil {
+			t.Fatalf("PutDecision returned error: %v", err)
+		}
+	}
+	if _, err := srv.PutDecision(ctx, &explorepb.PutDecisionRequest{ActorUserId: "user1", RecipientUserId: "actor1", LikedRecipient: true}); err != nil {
+		t.Fatalf("PutDecision returned error: %v", err)
+	}
+
+	stream := &exportStream{ctx: ctx}
+	if err := admin.ExportUserData(&explorepb.ExportUserDataRequest{UserId: "actor1"}, stream); err != nil {
+		t.Fatalf("ExportUserData returned error: %v", err)
+	}
+	var decisions, history, matches int
+	for i, msg := range stream.sent {
+		if (i == 0) != (msg.GetSummary() != nil) {
+			t.Errorf("expected the summary in the first message only, got %v at %d", msg, i)
+		}
+		decisions += len(msg.GetDecisions())
+		history += len(msg.GetHistory())
+		matches += len(msg.GetMatches())
+	}
+	if decisions != 3 || history != 3 || matches != 1 {
+		t.Errorf("expected 3 decisions, 3 history events and 1 match, got %d, %d and %d", decisions, history, matches)
+	}
+	if n := len(stream.sent); n != 6 {
+		t.Errorf("expected 6 messages in batches of 2, got %d", n)
+	}
+	if got := stream.sent[0].GetSummary(); got.GetLikes() != 1 || got.GetMatches() != 1 {
+		t.Errorf("expected 1 like and 1 match in the summary, got %v", got)
+	}
+
+	resp, err := admin.DeleteUserData(ctx, &explorepb.DeleteUserDataRequest{UserId: "actor1"})
+	if err != nil {
+		t.Fatalf("DeleteUserData returned error: %v", err)
+	}
+	if resp.GetDecisions() != 4 || resp.GetDecisionEvents() != 4 || resp.GetMatches() != 1 || resp.GetCompletedUnixTimestamp() == 0 {
+		t.Errorf("expected 4 decisions, 4 events and 1 match to be deleted, got %v", resp)
+	}
+	count, err := srv.CountLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user2"})
+	if err != nil {
+		t.Fatalf("CountLikedYou returned error: %v", err)
+	}
+	if count.GetCount() != 0 {
+		t.Errorf("expected the erased user's like to be gone, got %d", count.GetCount())
+	}
+}
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"explore_service/internal/storage"
+)
+
+// everything is a time range that matches every decision but is not
+// the zero TimeRange, so counts restricted to it are always computed
+// from decisions rather than read from counters.
+var everything = storage.TimeRange{Since: time.Unix(1, 0)}
+
+// checkLikeCounters fails the test if the user's unbounded counts and
+// summary disagree with counts computed from decisions and matches.
+func checkLikeCounters(t *testing.T, store storage.DecisionStore, userID string) {
+	t.Helper()
+	ctx := context.Background()
+	var want storage.LikeSummary
+	var err error
+	if want.Likes, err = store.CountLikedYou(ctx, userID, everything); err != nil {
+		t.Fatalf("CountLikedYou returned error: %v", err)
+	}
+	if want.NewLikes, err = store.CountNewLikedYou(ctx, userID, everything); err != nil {
+		t.Fatalf("CountNewLikedYou returned error: %v", err)
+	}
+	for after := (*storage.Cursor)(nil); ; {
+		matches, next, err := store.ListMatches(ctx, userID, after, 100)
+		if err != nil {
+			t.Fatalf("ListMatches returned error: %v", err)
+		}
+		want.Matches += uint64(len(matches))
+		if next == nil {
+			break
+		}
+		after = next
+	}
+
+	sum, err := store.GetLikeSummary(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetLikeSummary returned error: %v", err)
+	}
+	if sum != want {
+		t.Errorf("expected summary %+v for %s, got %+v", want, userID, sum)
+	}
+	if n, err := store.CountLikedYou(ctx, userID, storage.TimeRange{}); err != nil || n != want.Likes {
+		t.Errorf("expected %d likes for %s, got %d (err %v)", want.Likes, userID, n, err)
+	}
+	if n, err := store.CountNewLikedYou(ctx, userID, storage.TimeRange{}); err != nil || n != want.NewLikes {
+		t.Errorf("expected %d new likes for %s, got %d (err %v)", want.NewLikes, userID, n, err)
+	}
+}
+
+// applyRandomDecisions records n random likes and passes between
+// users, so every counter transition is exercised many times.
+func applyRandomDecisions(t *testing.T, store storage.DecisionStore, users []string, n int) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < n; i++ {
+		actor, recipient := users[rng.Intn(len(users))], users[rng.Intn(len(users))]
+		if actor == recipient {
+			continue
+		}
+		if _, err := store.PutDecision(context.Background(), actor, recipient, likeOrPass(rng.Intn(2) == 0)); err != nil {
+			t.Fatalf("PutDecision returned error: %v", err)
+		}
+	}
+}
+
+// TestLikeCounters checks that the counters kept by PutDecision agree
+// with counting decisions, and that RepairLikeCounters fixes counters
+// that have drifted.
+func TestLikeCounters(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		store := storage.NewMemoryStore()
+		id := userIDs(t)
+		users := []string{id("a"), id("b"), id("c"), id("d"), id("e")}
+		applyRandomDecisions(t, store, users, 500)
+		for _, u := range users {
+			checkLikeCounters(t, store, u)
+		}
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		ctx := context.Background()
+		pool, cleanup := startPostgres(ctx, t)
+		t.Cleanup(cleanup)
+		store, err := storage.NewStore(ctx, pool, storage.WithLikeCounters())
+		if err != nil {
+			t.Fatalf("failed to initialise store: %v", err)
+		}
+		id := userIDs(t)
+		users := []string{id("a"), id("b"), id("c"), id("d"), id("e")}
+		applyRandomDecisions(t, store, users, 500)
+		for _, u := range users {
+			checkLikeCounters(t, store, u)
+		}
+
+		const corrupt = `
+UPDATE user_like_counters
+SET likes_received = likes_received + 7, new_likes = 0, matches = matches + 1
+WHERE user_id = $1;
+        `
+		if _, err := pool.Exec(ctx, corrupt, users[0]); err != nil {
+			t.Fatalf("failed to corrupt counters: %v", err)
+		}
+		if _, err := pool.Exec(ctx, `DELETE FROM user_like_counters WHERE user_id = $1;`, users[1]); err != nil {
+			t.Fatalf("failed to delete counters: %v", err)
+		}
+		n, err := store.RepairLikeCounters(ctx)
+		if err != nil {
+			t.Fatalf("RepairLikeCounters returned error: %v", err)
+		}
+		if n == 0 {
+			t.Errorf("expected RepairLikeCounters to write rows")
+		}
+		for _, u := range users {
+			checkLikeCounters(t, store, u)
+		}
+	})
+}
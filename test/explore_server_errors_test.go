@@ -0,0 +1,459 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"explore_service/internal/server"
+	"explore_service/internal/storage"
+	explorepb "explore_service/proto"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingStore is a DecisionStore whose methods all fail with err.
+// Methods it does not override panic through the nil embedded
+// interface, which flags handlers that reach the store unexpectedly.
+type failingStore struct {
+	storage.DecisionStore
+	err error
+}
+
+func (f failingStore) PutDecision(context.Context, string, string, storage.DecisionType) (bool, error) {
+	return false, f.err
+}
+
+func (f failingStore) GetDecision(context.Context, string, string) (storage.StoredDecision, bool, error) {
+	return storage.StoredDecision{}, false, f.err
+}
+
+func (f failingStore) ListMyDecisions(context.Context, string, storage.DecisionFilter, *storage.Cursor, int) ([]storage.StoredDecision, *storage.Cursor, error) {
+	return nil, nil, f.err
+}
+
+func (f failingStore) FilterUndecided(context.Context, string, []string) ([]string, error) {
+	return nil, f.err
+}
+
+func (f failingStore) PutDecisions(context.Context, string, []storage.Decision) ([]storage.DecisionResult, error) {
+	return nil, f.err
+}
+
+func (f failingStore) RewindDecision(context.Context, string, time.Duration) (storage.Rewind, error) {
+	return storage.Rewind{}, f.err
+}
+
+func (f failingStore) BlockUser(context.Context, string, string) error {
+	return f.err
+}
+
+func (f failingStore) UnblockUser(context.Context, string, string) error {
+	return f.err
+}
+
+func (f failingStore) ListBlocked(context.Context, string, *storage.Cursor, int) ([]storage.BlockedUser, *storage.Cursor, error) {
+	return nil, nil, f.err
+}
+
+func (f failingStore) SetUserVisibility(context.Context, string, bool) error {
+	return f.err
+}
+
+func (f failingStore) DeleteUserData(context.Context, string, int) (storage.Erasure, error) {
+	return storage.Erasure{}, f.err
+}
+
+func (f failingStore) ExportUserData(context.Context, string, int, func(storage.UserDataExport) error) error {
+	return f.err
+}
+
+func (f failingStore) ListLikedYou(context.Context, string, storage.TimeRange, *storage.Cursor, int, storage.Order) ([]storage.Liker, *storage.Cursor, error) {
+	return nil, nil, f.err
+}
+
+func (f failingStore) ListNewLikedYou(context.Context, string, storage.TimeRange, *storage.Cursor, int, storage.Order) ([]storage.Liker, *storage.Cursor, error) {
+	return nil, nil, f.err
+}
+
+func (f failingStore) CountLikedYou(context.Context, string, storage.TimeRange) (uint64, error) {
+	return 0, f.err
+}
+
+func (f failingStore) CountNewLikedYou(context.Context, string, storage.TimeRange) (uint64, error) {
+	return 0, f.err
+}
+
+func (f failingStore) GetLikeSummary(context.Context, string) (storage.LikeSummary, error) {
+	return storage.LikeSummary{}, f.err
+}
+
+func (f failingStore) ListMatches(context.Context, string, *storage.Cursor, int) ([]storage.Match, *storage.Cursor, error) {
+	return nil, nil, f.err
+}
+
+func (f failingStore) GetDecisionHistory(context.Context, string, string) ([]storage.DecisionEvent, error) {
+	return nil, f.err
+}
+
+func (f failingStore) ListLikeChanges(context.Context, string, int64, int) ([]storage.LikeChange, error) {
+	return nil, f.err
+}
+
+func (f failingStore) LatestLikeChange(context.Context, string) (int64, error) {
+	return 0, f.err
+}
+
+func (f failingStore) SubscribeLikedYou(string) (<-chan struct{}, func()) {
+	return make(chan struct{}), func() {}
+}
+
+// watchStream is a server stream for WatchLikedYou that records the
+// messages sent on it.
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*explorepb.WatchLikedYouResponse
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(resp *explorepb.WatchLikedYouResponse) error {
+	w.sent = append(w.sent, resp)
+	return nil
+}
+
+// exportStream is a server stream for ExportUserData that records the
+// messages sent on it.
+type exportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*explorepb.ExportUserDataResponse
+}
+
+func (e *exportStream) Context() context.Context {
+	return e.ctx
+}
+
+func (e *exportStream) Send(resp *explorepb.ExportUserDataResponse) error {
+	e.sent = append(e.sent, resp)
+	return nil
+}
+
+// itemFailingStore is a DecisionStore whose PutDecisions stores
+// nothing and reports err for every decision.
+type itemFailingStore struct {
+	failingStore
+}
+
+func (f itemFailingStore) PutDecisions(_ context.Context, _ string, decisions []storage.Decision) ([]storage.DecisionResult, error) {
+	results := make([]storage.DecisionResult, len(decisions))
+	for i := range results {
+		results[i].Err = f.err
+	}
+	return results, nil
+}
+
+// TestValidation checks that malformed requests are rejected with
+// InvalidArgument before reaching the store.
+func TestValidation(t *testing.T) {
+	ctx := context.Background()
+	srv := server.NewExploreServer(failingStore{}, 10)
+	admin := server.NewAdminServer(failingStore{}, 10)
+	long := strings.Repeat("x", 129)
+	decisions := map[string]*explorepb.PutDecisionRequest{
+		"empty actor":         {RecipientUserId: "user1"},
+		"empty recipient":     {ActorUserId: "actor1"},
+		"oversized actor":     {ActorUserId: long, RecipientUserId: "user1"},
+		"oversized recipient": {ActorUserId: "actor1", RecipientUserId: long},
+		"invalid utf8":        {ActorUserId: "actor\xff", RecipientUserId: "user1"},
+		"self decision":       {ActorUserId: "user1", RecipientUserId: "user1", LikedRecipient: true},
+	}
+	for name, key := range map[string]string{"empty": "", "oversized": long, "invalid utf8": "key\xff"} {
+		decisions[name+" idempotency key"] = &explorepb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: "user1", IdempotencyKey: &key}
+	}
+	for name, req := range decisions {
+		if _, err := srv.PutDecision(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PutDecision(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if req.IdempotencyKey != nil {
+			continue
+		}
+		history := &explorepb.GetDecisionHistoryRequest{ActorUserId: req.GetActorUserId(), RecipientUserId: req.GetRecipientUserId()}
+		if _, err := srv.GetDecisionHistory(ctx, history); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetDecisionHistory(%s): expected InvalidArgument, got %v", name, err)
+		}
+		get := &explorepb.GetDecisionRequest{ActorUserId: req.GetActorUserId(), RecipientUserId: req.GetRecipientUserId()}
+		if _, err := srv.GetDecision(ctx, get); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetDecision(%s): expected InvalidArgument, got %v", name, err)
+		}
+		block := &explorepb.BlockUserRequest{UserId: req.GetActorUserId(), BlockedUserId: req.GetRecipientUserId()}
+		if _, err := srv.BlockUser(ctx, block); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("BlockUser(%s): expected InvalidArgument, got %v", name, err)
+		}
+		unblock := &explorepb.UnblockUserRequest{UserId: req.GetActorUserId(), BlockedUserId: req.GetRecipientUserId()}
+		if _, err := srv.UnblockUser(ctx, unblock); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("UnblockUser(%s): expected InvalidArgument, got %v", name, err)
+		}
+	}
+	for name, id := range map[string]string{"empty": "", "oversized": long} {
+		if _, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListLikedYou(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.ListNewLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListNewLikedYou(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.CountLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CountLikedYou(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.CountNewLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CountNewLikedYou(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.GetLikeSummary(ctx, &explorepb.GetLikeSummaryRequest{UserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetLikeSummary(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.ListMatches(ctx, &explorepb.ListMatchesRequest{UserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListMatches(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if err := srv.WatchLikedYou(&explorepb.WatchLikedYouRequest{RecipientUserId: id}, &watchStream{ctx: ctx}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("WatchLikedYou(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.ListMyDecisions(ctx, &explorepb.ListMyDecisionsRequest{ActorUserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListMyDecisions(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.RewindDecision(ctx, &explorepb.RewindDecisionRequest{ActorUserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("RewindDecision(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.ListBlocked(ctx, &explorepb.ListBlockedRequest{UserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListBlocked(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := srv.SetUserVisibility(ctx, &explorepb.SetUserVisibilityRequest{UserId: id, Hidden: true}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SetUserVisibility(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if _, err := admin.DeleteUserData(ctx, &explorepb.DeleteUserDataRequest{UserId: id}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("DeleteUserData(%s): expected InvalidArgument, got %v", name, err)
+		}
+		if err := admin.ExportUserData(&explorepb.ExportUserDataRequest{UserId: id}, &exportStream{ctx: ctx}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ExportUserData(%s): expected InvalidArgument, got %v", name, err)
+		}
+	}
+	for name, req := range map[string]*explorepb.PutDecisionRequest{
+		"pass with liked_recipient": {ActorUserId: "actor1", RecipientUserId: "user1", LikedRecipient: true, DecisionType: explorepb.DecisionType_DECISION_TYPE_PASS},
+		"unknown decision_type":     {ActorUserId: "actor1", RecipientUserId: "user1", DecisionType: explorepb.DecisionType(99)},
+	} {
+		if _, err := srv.PutDecision(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PutDecision(%s): expected InvalidArgument, got %v", name, err)
+		}
+	}
+	for name, req := range map[string]*explorepb.FilterUndecidedRequest{
+		"empty actor":         {CandidateIds: []string{"user1"}},
+		"empty candidate":     {ActorUserId: "actor1", CandidateIds: []string{"user1", ""}},
+		"too many":            {ActorUserId: "actor1", CandidateIds: make([]string, 10001)},
+		"oversized candidate": {ActorUserId: "actor1", CandidateIds: []string{long}},
+	} {
+		if _, err := srv.FilterUndecided(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("FilterUndecided(%s): expected InvalidArgument, got %v", name, err)
+		}
+	}
+	badToken := "not-a-token"
+	for name, req := range map[string]*explorepb.ListMyDecisionsRequest{
+		"invalid filter": {ActorUserId: "actor1", Filter: explorepb.DecisionFilter(7)},
+		"invalid token":  {ActorUserId: "actor1", PaginationToken: &badToken},
+	} {
+		if _, err := srv.ListMyDecisions(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListMyDecisions(%s): expected InvalidArgument, got %v", name, err)
+		}
+	}
+	for _, token := range []string{"", "not-a-token", storage.Cursor{UserID: "actor1"}.Encode()} {
+		req := &explorepb.WatchLikedYouRequest{RecipientUserId: "user1", ResumeToken: &token}
+		if err := srv.WatchLikedYou(req, &watchStream{ctx: ctx}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("WatchLikedYou(resume token %q): expected InvalidArgument, got %v", token, err)
+		}
+	}
+	tooMany := make([]*explorepb.PutDecisionsRequest_Decision, 3)
+	for i := range tooMany {
+		tooMany[i] = &explorepb.PutDecisionsRequest_Decision{RecipientUserId: "user1"}
+	}
+	batches := map[string]*explorepb.PutDecisionsRequest{
+		"empty actor":  {Decisions: tooMany[:1]},
+		"no decisions": {ActorUserId: "actor1"},
+		"too many":     {ActorUserId: "actor1", Decisions: tooMany},
+	}
+	small := server.NewExploreServer(failingStore{}, 10, server.WithMaxBatchSize(2))
+	for name, req := range batches {
+		if _, err := small.PutDecisions(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("PutDecisions(%s): expected InvalidArgument, got %v", name, err)
+		}
+	}
+	// The longest permitted identifier is accepted.
+	ok := server.NewExploreServer(storage.NewMemoryStore(), 10)
+	if _, err := ok.PutDecision(ctx, &explorepb.PutDecisionRequest{ActorUserId: long[:128], RecipientUserId: "user1"}); err != nil {
+		t.Errorf("PutDecision with a 128 byte id returned error: %v", err)
+	}
+	// Invalid decisions in a batch are reported individually and do
+	// not stop the valid ones from being stored.
+	resp, err := ok.PutDecisions(ctx, &explorepb.PutDecisionsRequest{
+		ActorUserId: "actor1",
+		Decisions: []*explorepb.PutDecisionsRequest_Decision{
+			{RecipientUserId: "actor1", LikedRecipient: true},
+			{RecipientUserId: "user2", LikedRecipient: true},
+			{RecipientUserId: long},
+			{RecipientUserId: "user3", DecisionType: explorepb.DecisionType(99)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("PutDecisions returned error: %v", err)
+	}
+	var got []codes.Code
+	for _, r := range resp.GetResults() {
+		got = append(got, codes.Code(r.GetCode()))
+	}
+	if want := []codes.Code{codes.InvalidArgument, codes.OK, codes.InvalidArgument, codes.InvalidArgument}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected result codes %v, got %v", want, got)
+	}
+	if msg := resp.GetResults()[0].GetErrorMessage(); msg == "" {
+		t.Errorf("expected an error message for the self decision")
+	}
+	count, err := ok.CountLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user2"})
+	if err != nil || count.GetCount() != 1 {
+		t.Errorf("expected the valid decision to be stored, got %v and %v", count, err)
+	}
+}
+
+// TestStorageErrorCodes checks that storage failures are mapped to
+// gRPC status codes without leaking the underlying error text.
+func TestStorageErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	const secret = "relation decisions on host db.internal"
+	cases := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"canceled", context.Canceled, codes.Canceled},
+		{"statement timeout", &pgconn.PgError{Code: "57014", Message: secret}, codes.DeadlineExceeded},
+		{"connection failure", &pgconn.PgError{Code: "08006", Message: secret}, codes.Unavailable},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01", Message: secret}, codes.Unavailable},
+		{"network", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New(secret)}, codes.Unavailable},
+		{"serialization", &pgconn.PgError{Code: "40001", Message: secret}, codes.Aborted},
+		{"too many connections", &pgconn.PgError{Code: "53300", Message: secret}, codes.ResourceExhausted},
+		{"undefined table", &pgconn.PgError{Code: "42P01", Message: secret}, codes.Internal},
+		{"unknown", errors.New(secret), codes.Internal},
+	}
+	batch := &explorepb.PutDecisionsRequest{
+		ActorUserId: "actor1",
+		Decisions:   []*explorepb.PutDecisionsRequest_Decision{{RecipientUserId: "user1"}},
+	}
+	for _, c := range cases {
+		srv := server.NewExploreServer(failingStore{err: c.err}, 10)
+		calls := map[string]func() error{
+			"PutDecision": func() error {
+				_, err := srv.PutDecision(ctx, &explorepb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: "user1"})
+				return err
+			},
+			"ListLikedYou": func() error {
+				_, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1"})
+				return err
+			},
+			"ListNewLikedYou": func() error {
+				_, err := srv.ListNewLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1"})
+				return err
+			},
+			"CountLikedYou": func() error {
+				_, err := srv.CountLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user1"})
+				return err
+			},
+			"CountNewLikedYou": func() error {
+				_, err := srv.CountNewLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user1"})
+				return err
+			},
+			"GetLikeSummary": func() error {
+				_, err := srv.GetLikeSummary(ctx, &explorepb.GetLikeSummaryRequest{UserId: "user1"})
+				return err
+			},
+			"ListMatches": func() error {
+				_, err := srv.ListMatches(ctx, &explorepb.ListMatchesRequest{UserId: "user1"})
+				return err
+			},
+			"GetDecisionHistory": func() error {
+				_, err := srv.GetDecisionHistory(ctx, &explorepb.GetDecisionHistoryRequest{ActorUserId: "actor1", RecipientUserId: "user1"})
+				return err
+			},
+			"WatchLikedYou": func() error {
+				return srv.WatchLikedYou(&explorepb.WatchLikedYouRequest{RecipientUserId: "user1"}, &watchStream{ctx: ctx})
+			},
+			"GetDecision": func() error {
+				_, err := srv.GetDecision(ctx, &explorepb.GetDecisionRequest{ActorUserId: "actor1", RecipientUserId: "user1"})
+				return err
+			},
+			"ListMyDecisions": func() error {
+				_, err := srv.ListMyDecisions(ctx, &explorepb.ListMyDecisionsRequest{ActorUserId: "actor1"})
+				return err
+			},
+			"FilterUndecided": func() error {
+				_, err := srv.FilterUndecided(ctx, &explorepb.FilterUndecidedRequest{ActorUserId: "actor1", CandidateIds: []string{"user1"}})
+				return err
+			},
+			"RewindDecision": func() error {
+				_, err := srv.RewindDecision(ctx, &explorepb.RewindDecisionRequest{ActorUserId: "actor1"})
+				return err
+			},
+			"BlockUser": func() error {
+				_, err := srv.BlockUser(ctx, &explorepb.BlockUserRequest{UserId: "actor1", BlockedUserId: "user1"})
+				return err
+			},
+			"UnblockUser": func() error {
+				_, err := srv.UnblockUser(ctx, &explorepb.UnblockUserRequest{UserId: "actor1", BlockedUserId: "user1"})
+				return err
+			},
+			"ListBlocked": func() error {
+				_, err := srv.ListBlocked(ctx, &explorepb.ListBlockedRequest{UserId: "actor1"})
+				return err
+			},
+			"SetUserVisibility": func() error {
+				_, err := srv.SetUserVisibility(ctx, &explorepb.SetUserVisibilityRequest{UserId: "user1", Hidden: true})
+				return err
+			},
+			"DeleteUserData": func() error {
+				_, err := server.NewAdminServer(failingStore{err: c.err}, 10).DeleteUserData(ctx, &explorepb.DeleteUserDataRequest{UserId: "user1"})
+				return err
+			},
+			"ExportUserData": func() error {
+				return server.NewAdminServer(failingStore{err: c.err}, 10).ExportUserData(&explorepb.ExportUserDataRequest{UserId: "user1"}, &exportStream{ctx: ctx})
+			},
+			"PutDecisions": func() error {
+				_, err := srv.PutDecisions(ctx, batch)
+				return err
+			},
+			"PutDecisions item": func() error {
+				srv := server.NewExploreServer(itemFailingStore{failingStore{err: c.err}}, 10)
+				resp, err := srv.PutDecisions(ctx, batch)
+				if err != nil {
+					return err
+				}
+				r := resp.GetResults()[0]
+				return status.Error(codes.Code(r.GetCode()), r.GetErrorMessage())
+			},
+		}
+		for method, call := range calls {
+			err := call()
+			st, _ := status.FromError(err)
+			if st.Code() != c.want {
+				t.Errorf("%s(%s): expected %s, got %v", method, c.name, c.want, err)
+			}
+			if strings.Contains(st.Message(), "db.internal") {
+				t.Errorf("%s(%s): status message leaks storage error: %q", method, c.name, st.Message())
+			}
+		}
+	}
+}
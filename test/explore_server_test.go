@@ -2,6 +2,8 @@ package test
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"os"
 	"testing"
 
@@ -12,6 +14,8 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/testcontainers/testcontainers-go"
 	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // startPostgres spins up a temporary PostgreSQL container for testing.
@@ -33,6 +37,9 @@ func startPostgres(ctx context.Context, t *testing.T) (*pgxpool.Pool, func()) {
 		return pool, cleanup
 	}
 
+	// Without TEST_PG_DSN a container is required; skip rather than
+	// fail when Docker is not available.
+	testcontainers.SkipIfProviderIsNotHealthy(t)
 	// Create a new Postgres container with default credentials.
 	container, err := tcpostgres.RunContainer(ctx, testcontainers.WithImage("postgres:15"))
 	if err != nil {
@@ -57,16 +64,49 @@ func startPostgres(ctx context.Context, t *testing.T) (*pgxpool.Pool, func()) {
 	return pool, cleanup
 }
 
-// TestExploreServer exercises the core functionality of the ExploreService.
-func TestExploreServer(t *testing.T) {
+// newPostgresStore starts Postgres and returns a migrated Store.  The
+// database is released when the test finishes.
+func newPostgresStore(t *testing.T) storage.DecisionStore {
+	t.Helper()
+	return newPostgresStoreWith(t)
+}
+
+// newPostgresStoreWith is newPostgresStore for a Store configured with
+// opts.
+func newPostgresStoreWith(t *testing.T, opts ...storage.Option) *storage.Store {
+	t.Helper()
 	ctx := context.Background()
 	pool, cleanup := startPostgres(ctx, t)
-	defer cleanup()
-	// Initialise store and server.
-	store, err := storage.NewStore(ctx, pool)
+	t.Cleanup(cleanup)
+	store, err := storage.NewStore(ctx, pool, opts...)
 	if err != nil {
 		t.Fatalf("failed to initialise store: %v", err)
 	}
+	return store
+}
+
+// backends lists the DecisionStore implementations that the tests run
+// against.
+var backends = []struct {
+	name     string
+	newStore func(t *testing.T) storage.DecisionStore
+}{
+	{"memory", func(*testing.T) storage.DecisionStore { return storage.NewMemoryStore() }},
+	{"postgres", newPostgresStore},
+}
+
+// TestExploreServer exercises the core functionality of the
+// ExploreService against every storage backend.
+func TestExploreServer(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			testExploreServer(t, b.newStore(t))
+		})
+	}
+}
+
+func testExploreServer(t *testing.T, store storage.DecisionStore) {
+	ctx := context.Background()
 	srv := server.NewExploreServer(store, 10)
 	// Helper to call PutDecision.
 	put := func(actor, recipient string, like bool) bool {
@@ -122,6 +162,20 @@ func TestExploreServer(t *testing.T) {
 	if len(newResp.GetLikers()) != 2 {
 		t.Fatalf("expected 2 new likers, got %d", len(newResp.GetLikers()))
 	}
+	newCount, err := srv.CountNewLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user1"})
+	if err != nil {
+		t.Fatalf("CountNewLikedYou returned error: %v", err)
+	}
+	if newCount.GetCount() != 2 {
+		t.Errorf("expected new count 2, got %d", newCount.GetCount())
+	}
+	summary, err := srv.GetLikeSummary(ctx, &explorepb.GetLikeSummaryRequest{UserId: "user1"})
+	if err != nil {
+		t.Fatalf("GetLikeSummary returned error: %v", err)
+	}
+	if summary.GetLikes() != 3 || summary.GetNewLikes() != 2 || summary.GetMatches() != 1 {
+		t.Errorf("expected summary 3/2/1, got %v", summary)
+	}
 	// Check that actor2 and actor3 are present.
 	found := make(map[string]bool)
 	for _, l := range newResp.GetLikers() {
@@ -132,4 +186,433 @@ func TestExploreServer(t *testing.T) {
 			t.Errorf("expected to find %s in new likers", id)
 		}
 	}
+	// user1 and actor1 like each other again, so they are matched.
+	matchResp, err := srv.ListMatches(ctx, &explorepb.ListMatchesRequest{UserId: "user1"})
+	if err != nil {
+		t.Fatalf("ListMatches returned error: %v", err)
+	}
+	if len(matchResp.GetMatches()) != 1 || matchResp.GetMatches()[0].GetUserId() != "actor1" {
+		t.Errorf("expected user1 to be matched with actor1 only, got %v", matchResp.GetMatches())
+	}
+	// actor1 liked, passed and liked user1 again.
+	historyResp, err := srv.GetDecisionHistory(ctx, &explorepb.GetDecisionHistoryRequest{ActorUserId: "actor1", RecipientUserId: "user1"})
+	if err != nil {
+		t.Fatalf("GetDecisionHistory returned error: %v", err)
+	}
+	var history []bool
+	for _, e := range historyResp.GetEvents() {
+		history = append(history, e.GetLikedRecipient())
+	}
+	if len(history) != 3 || !history[0] || history[1] || !history[2] {
+		t.Errorf("expected history [true false true], got %v", history)
+	}
+	// actor1's own view: a like of user1 and nothing for actor2.
+	decision, err := srv.GetDecision(ctx, &explorepb.GetDecisionRequest{ActorUserId: "actor1", RecipientUserId: "user1"})
+	if err != nil {
+		t.Fatalf("GetDecision returned error: %v", err)
+	}
+	if !decision.GetDecided() || !decision.GetLikedRecipient() || decision.GetUnixTimestamp() == 0 {
+		t.Errorf("expected actor1 to like user1, got %v", decision)
+	}
+	decision, err = srv.GetDecision(ctx, &explorepb.GetDecisionRequest{ActorUserId: "actor1", RecipientUserId: "actor2"})
+	if err != nil {
+		t.Fatalf("GetDecision returned error: %v", err)
+	}
+	if decision.GetDecided() {
+		t.Errorf("expected no decision on actor2, got %v", decision)
+	}
+	mine, err := srv.ListMyDecisions(ctx, &explorepb.ListMyDecisionsRequest{ActorUserId: "user1", Filter: explorepb.DecisionFilter_DECISION_FILTER_LIKED})
+	if err != nil {
+		t.Fatalf("ListMyDecisions returned error: %v", err)
+	}
+	if len(mine.GetDecisions()) != 1 || mine.GetDecisions()[0].GetRecipientUserId() != "actor1" {
+		t.Errorf("expected user1 to have liked actor1 only, got %v", mine.GetDecisions())
+	}
+}
+
+// testOnBackends runs test against an ExploreServer with a page size
+// of 10 backed by each storage backend.
+func testOnBackends(t *testing.T, test func(t *testing.T, srv *server.ExploreServer)) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			test(t, server.NewExploreServer(b.newStore(t), 10))
+		})
+	}
+}
+
+// mustPutDecision calls PutDecision and fails the test on error.
+func mustPutDecision(t *testing.T, srv *server.ExploreServer, req *explorepb.PutDecisionRequest) *explorepb.PutDecisionResponse {
+	t.Helper()
+	resp, err := srv.PutDecision(context.Background(), req)
+	if err != nil {
+		t.Fatalf("PutDecision returned error: %v", err)
+	}
+	return resp
+}
+
+// TestIdempotencyKey checks that PutDecision retries with the same
+// idempotency key return the original result and that reusing a key
+// for another decision is rejected.
+func TestIdempotencyKey(t *testing.T) {
+	testOnBackends(t, func(t *testing.T, srv *server.ExploreServer) {
+		ctx := context.Background()
+		key := "swipe-1"
+		put := func(recipient string) *explorepb.PutDecisionRequest {
+			return &explorepb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: recipient, LikedRecipient: true, IdempotencyKey: &key}
+		}
+		mustPutDecision(t, srv, &explorepb.PutDecisionRequest{ActorUserId: "user1", RecipientUserId: "actor1", LikedRecipient: true})
+		for i := 0; i < 3; i++ {
+			if resp := mustPutDecision(t, srv, put("user1")); !resp.GetMutualLikes() {
+				t.Errorf("attempt %d: expected a mutual like", i)
+			}
+		}
+		history, err := srv.GetDecisionHistory(ctx, &explorepb.GetDecisionHistoryRequest{ActorUserId: "actor1", RecipientUserId: "user1"})
+		if err != nil {
+			t.Fatalf("GetDecisionHistory returned error: %v", err)
+		}
+		if len(history.GetEvents()) != 1 {
+			t.Errorf("expected one recorded decision, got %d", len(history.GetEvents()))
+		}
+		if _, err := srv.PutDecision(ctx, put("user2")); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition for a reused key, got %v", err)
+		}
+	})
+}
+
+// TestRewindDecision checks that RewindDecision undoes the latest
+// decision and reports when there is nothing left to undo.
+func TestRewindDecision(t *testing.T) {
+	testOnBackends(t, func(t *testing.T, srv *server.ExploreServer) {
+		ctx := context.Background()
+		rewind := &explorepb.RewindDecisionRequest{ActorUserId: "actor1"}
+		if _, err := srv.RewindDecision(ctx, rewind); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition without decisions, got %v", err)
+		}
+		for _, liked := range []bool{true, false} {
+			mustPutDecision(t, srv, &explorepb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: "user1", LikedRecipient: liked})
+		}
+		resp, err := srv.RewindDecision(ctx, rewind)
+		if err != nil {
+			t.Fatalf("RewindDecision returned error: %v", err)
+		}
+		if resp.GetRecipientUserId() != "user1" || resp.GetLikedRecipient() || resp.RestoredLikedRecipient == nil || !resp.GetRestoredLikedRecipient() {
+			t.Errorf("expected the pass to be undone and the like restored, got %v", resp)
+		}
+		resp, err = srv.RewindDecision(ctx, rewind)
+		if err != nil {
+			t.Fatalf("RewindDecision returned error: %v", err)
+		}
+		if !resp.GetLikedRecipient() || resp.RestoredLikedRecipient != nil {
+			t.Errorf("expected the like to be undone with nothing restored, got %v", resp)
+		}
+		if _, err := srv.RewindDecision(ctx, rewind); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition once everything is undone, got %v", err)
+		}
+	})
+}
+
+// TestSuperLike checks that decision_type selects the decision, that
+// clients sending only liked_recipient keep working and that super
+// likes beyond the quota are rejected.
+func TestSuperLike(t *testing.T) {
+	testOnBackends(t, func(t *testing.T, srv *server.ExploreServer) {
+		ctx := context.Background()
+		superLike := func(actor, recipient string) *explorepb.PutDecisionRequest {
+			return &explorepb.PutDecisionRequest{ActorUserId: actor, RecipientUserId: recipient, DecisionType: explorepb.DecisionType_DECISION_TYPE_SUPER_LIKE}
+		}
+		mustPutDecision(t, srv, superLike("actor1", "user1"))
+		mustPutDecision(t, srv, &explorepb.PutDecisionRequest{ActorUserId: "actor2", RecipientUserId: "user1", LikedRecipient: true})
+		likers, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1"})
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		superLikes := make(map[string]bool)
+		for _, l := range likers.GetLikers() {
+			superLikes[l.GetActorId()] = l.GetSuperLike()
+		}
+		if want := map[string]bool{"actor1": true, "actor2": false}; fmt.Sprint(superLikes) != fmt.Sprint(want) {
+			t.Errorf("expected super like flags %v, got %v", want, superLikes)
+		}
+		for actor, want := range map[string]explorepb.DecisionType{
+			"actor1": explorepb.DecisionType_DECISION_TYPE_SUPER_LIKE,
+			"actor2": explorepb.DecisionType_DECISION_TYPE_LIKE,
+		} {
+			resp, err := srv.GetDecision(ctx, &explorepb.GetDecisionRequest{ActorUserId: actor, RecipientUserId: "user1"})
+			if err != nil {
+				t.Fatalf("GetDecision returned error: %v", err)
+			}
+			if resp.GetDecisionType() != want || !resp.GetLikedRecipient() {
+				t.Errorf("expected %v for %s, got %v", want, actor, resp)
+			}
+		}
+		// Use up the rest of actor1's daily quota; the next super like
+		// is rejected.
+		for i := 2; i <= storage.DefaultSuperLikeQuota; i++ {
+			mustPutDecision(t, srv, superLike("actor1", fmt.Sprintf("user%d", i)))
+		}
+		_, err = srv.PutDecision(ctx, superLike("actor1", "over-quota"))
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("expected ResourceExhausted beyond the quota, got %v", err)
+		}
+		// A rewind reports whether the restored like is a super like.
+		mustPutDecision(t, srv, &explorepb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: "user1"})
+		rewind, err := srv.RewindDecision(ctx, &explorepb.RewindDecisionRequest{ActorUserId: "actor1"})
+		if err != nil {
+			t.Fatalf("RewindDecision returned error: %v", err)
+		}
+		if rewind.RestoredDecisionType == nil || rewind.GetRestoredDecisionType() != explorepb.DecisionType_DECISION_TYPE_SUPER_LIKE || !rewind.GetRestoredLikedRecipient() {
+			t.Errorf("expected the super like to be restored, got %v", rewind)
+		}
+	})
+}
+
+// TestBlockUser checks that a block hides a liker and that blocked
+// users are listed until the block is lifted.
+func TestBlockUser(t *testing.T) {
+	testOnBackends(t, func(t *testing.T, srv *server.ExploreServer) {
+		ctx := context.Background()
+		for _, actor := range []string{"actor1", "actor2"} {
+			mustPutDecision(t, srv, &explorepb.PutDecisionRequest{ActorUserId: actor, RecipientUserId: "user1", LikedRecipient: true})
+		}
+		if _, err := srv.BlockUser(ctx, &explorepb.BlockUserRequest{UserId: "user1", BlockedUserId: "actor1"}); err != nil {
+			t.Fatalf("BlockUser returned error: %v", err)
+		}
+		count, err := srv.CountLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user1"})
+		if err != nil {
+			t.Fatalf("CountLikedYou returned error: %v", err)
+		}
+		if count.GetCount() != 1 {
+			t.Errorf("expected 1 like while blocked, got %d", count.GetCount())
+		}
+		blocked, err := srv.ListBlocked(ctx, &explorepb.ListBlockedRequest{UserId: "user1"})
+		if err != nil {
+			t.Fatalf("ListBlocked returned error: %v", err)
+		}
+		if got := blocked.GetBlockedUsers(); len(got) != 1 || got[0].GetUserId() != "actor1" || got[0].GetUnixTimestamp() == 0 {
+			t.Errorf("expected actor1 to be blocked, got %v", got)
+		}
+		if _, err := srv.UnblockUser(ctx, &explorepb.UnblockUserRequest{UserId: "user1", BlockedUserId: "actor1"}); err != nil {
+			t.Fatalf("UnblockUser returned error: %v", err)
+		}
+		likers, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1"})
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		if n := len(likers.GetLikers()); n != 2 {
+			t.Errorf("expected 2 likers after unblocking, got %d", n)
+		}
+		blocked, err = srv.ListBlocked(ctx, &explorepb.ListBlockedRequest{UserId: "user1"})
+		if err != nil {
+			t.Fatalf("ListBlocked returned error: %v", err)
+		}
+		if got := blocked.GetBlockedUsers(); len(got) != 0 {
+			t.Errorf("expected nobody to be blocked, got %v", got)
+		}
+	})
+}
+
+// TestSetUserVisibility checks that a hidden user's likes are left out
+// of listings and counts until the user is shown again.
+func TestSetUserVisibility(t *testing.T) {
+	testOnBackends(t, func(t *testing.T, srv *server.ExploreServer) {
+		ctx := context.Background()
+		for _, actor := range []string{"actor1", "actor2"} {
+			mustPutDecision(t, srv, &explorepb.PutDecisionRequest{ActorUserId: actor, RecipientUserId: "user1", LikedRecipient: true})
+		}
+		count := func(t *testing.T) uint64 {
+			t.Helper()
+			resp, err := srv.CountLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user1"})
+			if err != nil {
+				t.Fatalf("CountLikedYou returned error: %v", err)
+			}
+			return resp.GetCount()
+		}
+		if _, err := srv.SetUserVisibility(ctx, &explorepb.SetUserVisibilityRequest{UserId: "actor1", Hidden: true}); err != nil {
+			t.Fatalf("SetUserVisibility returned error: %v", err)
+		}
+		if n := count(t); n != 1 {
+			t.Errorf("expected 1 like while actor1 is hidden, got %d", n)
+		}
+		likers, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1"})
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		if got := likers.GetLikers(); len(got) != 1 || got[0].GetActorId() != "actor2" {
+			t.Errorf("expected only actor2 while actor1 is hidden, got %v", got)
+		}
+		if _, err := srv.SetUserVisibility(ctx, &explorepb.SetUserVisibilityRequest{UserId: "actor1"}); err != nil {
+			t.Fatalf("SetUserVisibility returned error: %v", err)
+		}
+		if n := count(t); n != 2 {
+			t.Errorf("expected 2 likes once actor1 is shown, got %d", n)
+		}
+	})
+}
+
+// TestPaginationTokens checks that list tokens round-trip through the
+// server and that malformed tokens are rejected.
+func TestPaginationTokens(t *testing.T) {
+	ctx := context.Background()
+	srv := server.NewExploreServer(storage.NewMemoryStore(), 2)
+	for _, actor := range []string{"actor1", "actor2", "actor3"} {
+		if _, err := srv.PutDecision(ctx, &explorepb.PutDecisionRequest{
+			ActorUserId:     actor,
+			RecipientUserId: "user1",
+			LikedRecipient:  true,
+		}); err != nil {
+			t.Fatalf("PutDecision returned error: %v", err)
+		}
+	}
+	first, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1"})
+	if err != nil {
+		t.Fatalf("ListLikedYou returned error: %v", err)
+	}
+	if len(first.GetLikers()) != 2 || first.NextPaginationToken == nil {
+		t.Fatalf("expected a full first page with a token, got %v", first)
+	}
+	token := first.GetNextPaginationToken()
+	second, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1", PaginationToken: &token})
+	if err != nil {
+		t.Fatalf("ListLikedYou returned error: %v", err)
+	}
+	if len(second.GetLikers()) != 1 || second.NextPaginationToken != nil {
+		t.Fatalf("expected a final page of one liker, got %v", second)
+	}
+	if got := second.GetLikers()[0].GetActorId(); got != "actor1" {
+		t.Errorf("expected actor1 on the last page, got %s", got)
+	}
+	for _, bad := range []string{"10", "not a token", "AA"} {
+		if _, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1", PaginationToken: &bad}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListLikedYou(%q): expected InvalidArgument, got %v", bad, err)
+		}
+		if _, err := srv.ListNewLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1", PaginationToken: &bad}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListNewLikedYou(%q): expected InvalidArgument, got %v", bad, err)
+		}
+	}
+}
+
+// TestListLikedYouOptions checks page_size clamping and that the order
+// is fixed by the pagination token.
+func TestListLikedYouOptions(t *testing.T) {
+	ctx := context.Background()
+	srv := server.NewExploreServer(storage.NewMemoryStore(), 2, server.WithMaxPageSize(3))
+	for _, actor := range []string{"actor1", "actor2", "actor3", "actor4"} {
+		if _, err := srv.PutDecision(ctx, &explorepb.PutDecisionRequest{
+			ActorUserId:     actor,
+			RecipientUserId: "user1",
+			LikedRecipient:  true,
+		}); err != nil {
+			t.Fatalf("PutDecision returned error: %v", err)
+		}
+	}
+	list := func(req *explorepb.ListLikedYouRequest) ([]string, *string) {
+		t.Helper()
+		req.RecipientUserId = "user1"
+		resp, err := srv.ListLikedYou(ctx, req)
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		var ids []string
+		for _, l := range resp.GetLikers() {
+			ids = append(ids, l.GetActorId())
+		}
+		return ids, resp.NextPaginationToken
+	}
+	pageSize := func(n uint32) *uint32 { return &n }
+	for _, c := range []struct {
+		size *uint32
+		want int
+	}{{nil, 2}, {pageSize(0), 2}, {pageSize(1), 1}, {pageSize(3), 3}, {pageSize(1000), 3}} {
+		if ids, _ := list(&explorepb.ListLikedYouRequest{PageSize: c.size}); len(ids) != c.want {
+			t.Errorf("page_size %v: expected %d likers, got %v", c.size, c.want, ids)
+		}
+	}
+
+	ids, token := list(&explorepb.ListLikedYouRequest{Order: explorepb.Order_ORDER_OLDEST_FIRST})
+	if want := []string{"actor1", "actor2"}; fmt.Sprint(ids) != fmt.Sprint(want) || token == nil {
+		t.Fatalf("expected %v and a token, got %v", want, ids)
+	}
+	// The token keeps the order without restating it.
+	ids, _ = list(&explorepb.ListLikedYouRequest{PaginationToken: token})
+	if want := []string{"actor3", "actor4"}; fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("expected %v on the second page, got %v", want, ids)
+	}
+	ids, _ = list(&explorepb.ListLikedYouRequest{PaginationToken: token, Order: explorepb.Order_ORDER_OLDEST_FIRST})
+	if want := []string{"actor3", "actor4"}; fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("expected %v on the second page, got %v", want, ids)
+	}
+	// Tokens issued before orders existed continue newest first.
+	legacy := base64.RawURLEncoding.EncodeToString(append([]byte{1, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "actor9"...))
+	ids, _ = list(&explorepb.ListLikedYouRequest{PaginationToken: &legacy})
+	if want := []string{"actor4", "actor3"}; fmt.Sprint(ids) != fmt.Sprint(want) {
+		t.Errorf("expected %v after a version 1 token, got %v", want, ids)
+	}
+	for _, req := range []*explorepb.ListLikedYouRequest{
+		{RecipientUserId: "user1", PaginationToken: token, Order: explorepb.Order_ORDER_NEWEST_FIRST},
+		{RecipientUserId: "user1", Order: explorepb.Order(7)},
+	} {
+		if _, err := srv.ListLikedYou(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListLikedYou(%v): expected InvalidArgument, got %v", req, err)
+		}
+		if _, err := srv.ListNewLikedYou(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListNewLikedYou(%v): expected InvalidArgument, got %v", req, err)
+		}
+	}
+}
+
+// TestTimeRange checks that since and until select likers by the
+// timestamp the service reports for them, since inclusive and until
+// exclusive.
+func TestTimeRange(t *testing.T) {
+	ctx := context.Background()
+	srv := server.NewExploreServer(storage.NewMemoryStore(), 10)
+	if _, err := srv.PutDecision(ctx, &explorepb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: "user1", LikedRecipient: true}); err != nil {
+		t.Fatalf("PutDecision returned error: %v", err)
+	}
+	all, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1"})
+	if err != nil || len(all.GetLikers()) != 1 {
+		t.Fatalf("expected one liker, got %v (err %v)", all, err)
+	}
+	ts := all.GetLikers()[0].GetUnixTimestamp()
+	bound := func(v uint64) *uint64 { return &v }
+	for _, c := range []struct {
+		since, until *uint64
+		want         int
+	}{
+		{bound(ts), nil, 1},
+		{bound(ts + 1), nil, 0},
+		{nil, bound(ts), 0},
+		{nil, bound(ts + 1), 1},
+		{bound(ts), bound(ts + 1), 1},
+		{bound(ts), bound(ts), 0},
+	} {
+		list, err := srv.ListLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1", SinceUnixTimestamp: c.since, UntilUnixTimestamp: c.until})
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		newList, err := srv.ListNewLikedYou(ctx, &explorepb.ListLikedYouRequest{RecipientUserId: "user1", SinceUnixTimestamp: c.since, UntilUnixTimestamp: c.until})
+		if err != nil {
+			t.Fatalf("ListNewLikedYou returned error: %v", err)
+		}
+		count, err := srv.CountLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user1", SinceUnixTimestamp: c.since, UntilUnixTimestamp: c.until})
+		if err != nil {
+			t.Fatalf("CountLikedYou returned error: %v", err)
+		}
+		if len(list.GetLikers()) != c.want || len(newList.GetLikers()) != c.want || count.GetCount() != uint64(c.want) {
+			t.Errorf("since %v until %v: expected %d liker(s), got %d, %d and count %d", c.since, c.until, c.want,
+				len(list.GetLikers()), len(newList.GetLikers()), count.GetCount())
+		}
+	}
+	for name, req := range map[string]*explorepb.CountLikedYouRequest{
+		"inverted":     {RecipientUserId: "user1", SinceUnixTimestamp: bound(ts + 1), UntilUnixTimestamp: bound(ts)},
+		"out of range": {RecipientUserId: "user1", SinceUnixTimestamp: bound(1 << 62)},
+	} {
+		if _, err := srv.CountLikedYou(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CountLikedYou(%s): expected InvalidArgument, got %v", name, err)
+		}
+		list := &explorepb.ListLikedYouRequest{RecipientUserId: "user1", SinceUnixTimestamp: req.SinceUnixTimestamp, UntilUnixTimestamp: req.UntilUnixTimestamp}
+		if _, err := srv.ListLikedYou(ctx, list); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListLikedYou(%s): expected InvalidArgument, got %v", name, err)
+		}
+	}
 }
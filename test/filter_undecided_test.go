@@ -0,0 +1,74 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"explore_service/internal/server"
+	"explore_service/internal/storage"
+	explorepb "explore_service/proto"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BenchmarkFilterUndecided measures filtering a recommender sized batch
+// of candidates for an actor who has already decided on half of them.
+// The postgres variant runs against the database named by TEST_PG_DSN.
+func BenchmarkFilterUndecided(b *testing.B) {
+	b.Run("memory", func(b *testing.B) {
+		benchmarkFilterUndecided(b, storage.NewMemoryStore())
+	})
+	b.Run("postgres", func(b *testing.B) {
+		dsn := os.Getenv("TEST_PG_DSN")
+		if dsn == "" {
+			b.Skip("TEST_PG_DSN is not set")
+		}
+		ctx := context.Background()
+		pool, err := pgxpool.New(ctx, dsn)
+		if err != nil {
+			b.Fatalf("failed to create pgx pool from TEST_PG_DSN: %v", err)
+		}
+		defer pool.Close()
+		store, err := storage.NewStore(ctx, pool)
+		if err != nil {
+			b.Fatalf("failed to initialise store: %v", err)
+		}
+		benchmarkFilterUndecided(b, store)
+	})
+}
+
+func benchmarkFilterUndecided(b *testing.B, store storage.DecisionStore) {
+	const candidates = 5000
+	ctx := context.Background()
+	srv := server.NewExploreServer(store, 10)
+	prefix := fmt.Sprintf("%s/%d/", b.Name(), time.Now().UnixNano())
+	actor := prefix + "actor"
+	ids := make([]string, candidates)
+	var decisions []storage.Decision
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%scandidate%d", prefix, i)
+		if i%2 == 0 {
+			decisions = append(decisions, storage.Decision{RecipientID: ids[i], Type: likeOrPass(i%4 == 0)})
+		}
+		if len(decisions) == 100 || i == len(ids)-1 {
+			if _, err := store.PutDecisions(ctx, actor, decisions); err != nil {
+				b.Fatalf("PutDecisions returned error: %v", err)
+			}
+			decisions = decisions[:0]
+		}
+	}
+	req := &explorepb.FilterUndecidedRequest{ActorUserId: actor, CandidateIds: ids}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := srv.FilterUndecided(ctx, req)
+		if err != nil {
+			b.Fatalf("FilterUndecided returned error: %v", err)
+		}
+		if len(resp.GetUndecidedIds()) != candidates/2 {
+			b.Fatalf("expected %d undecided candidates, got %d", candidates/2, len(resp.GetUndecidedIds()))
+		}
+	}
+}
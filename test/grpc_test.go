@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"explore_service/internal/server"
+	"explore_service/internal/storage"
+	explorepb "explore_service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startGRPCServer serves an ExploreServer backed by store over an
+// in-memory listener with reflection enabled and returns a client
+// connection to it.  register can add further services.  Both are
+// shut down when the test finishes.
+func startGRPCServer(t *testing.T, store storage.DecisionStore, register ...func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	explorepb.RegisterExploreServiceServer(grpcServer, server.NewExploreServer(store, 10))
+	reflection.Register(grpcServer)
+	for _, r := range register {
+		r(grpcServer)
+	}
+	go func() { _ = grpcServer.Serve(lis) }()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial test server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		grpcServer.Stop()
+	})
+	return conn
+}
+
+// TestGeneratedClient exercises the service through the generated
+// ExploreServiceClient.
+func TestGeneratedClient(t *testing.T) {
+	ctx := context.Background()
+	client := explorepb.NewExploreServiceClient(startGRPCServer(t, storage.NewMemoryStore()))
+	if _, err := client.PutDecision(ctx, &explorepb.PutDecisionRequest{ActorUserId: "actor1", RecipientUserId: "user1", LikedRecipient: true}); err != nil {
+		t.Fatalf("PutDecision returned error: %v", err)
+	}
+	resp, err := client.PutDecision(ctx, &explorepb.PutDecisionRequest{ActorUserId: "user1", RecipientUserId: "actor1", LikedRecipient: true})
+	if err != nil {
+		t.Fatalf("PutDecision returned error: %v", err)
+	}
+	if !resp.GetMutualLikes() {
+		t.Errorf("expected mutual like over gRPC")
+	}
+	count, err := client.CountLikedYou(ctx, &explorepb.CountLikedYouRequest{RecipientUserId: "user1"})
+	if err != nil {
+		t.Fatalf("CountLikedYou returned error: %v", err)
+	}
+	if count.GetCount() != 1 {
+		t.Errorf("expected count 1, got %d", count.GetCount())
+	}
+}
+
+// TestReflection lists the registered services through gRPC server
+// reflection and resolves the ExploreService descriptor.
+func TestReflection(t *testing.T) {
+	ctx := context.Background()
+	client := reflectionpb.NewServerReflectionClient(startGRPCServer(t, storage.NewMemoryStore()))
+	stream, err := client.ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatalf("ServerReflectionInfo returned error: %v", err)
+	}
+	defer func() { _ = stream.CloseSend() }()
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatalf("failed to send list request: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive list response: %v", err)
+	}
+	var names []string
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		names = append(names, svc.GetName())
+	}
+	found := false
+	for _, name := range names {
+		found = found || name == "explore.ExploreService"
+	}
+	if !found {
+		t.Fatalf("expected explore.ExploreService in %v", names)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "explore.ExploreService"},
+	}); err != nil {
+		t.Fatalf("failed to send symbol request: %v", err)
+	}
+	resp, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive symbol response: %v", err)
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		t.Fatalf("reflection could not resolve ExploreService: %s", errResp.GetErrorMessage())
+	}
+	if len(resp.GetFileDescriptorResponse().GetFileDescriptorProto()) == 0 {
+		t.Errorf("expected a file descriptor for ExploreService")
+	}
+}
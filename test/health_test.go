@@ -0,0 +1,80 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"explore_service/internal/server"
+	"explore_service/internal/storage"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakeProbe is a ReadinessProbe whose result can be changed.
+type fakeProbe struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (p *fakeProbe) Ready(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *fakeProbe) set(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// TestHealthReporter checks that liveness and readiness are reported
+// separately and that shutdown flips everything to NOT_SERVING.
+func TestHealthReporter(t *testing.T) {
+	ctx := context.Background()
+	probe := &fakeProbe{err: errors.New("database down")}
+	reporter := server.NewHealthReporter(probe, time.Second)
+	client := healthpb.NewHealthClient(startGRPCServer(t, storage.NewMemoryStore(), reporter.Register))
+	expect := func(step, service string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("%s: Check(%q) returned error: %v", step, service, err)
+		}
+		if resp.GetStatus() != want {
+			t.Errorf("%s: expected %q to be %s, got %s", step, service, want, resp.GetStatus())
+		}
+	}
+	const (
+		serving    = healthpb.HealthCheckResponse_SERVING
+		notServing = healthpb.HealthCheckResponse_NOT_SERVING
+	)
+	// Before the first probe only liveness is reported as serving.
+	expect("initial", server.LivenessService, serving)
+	expect("initial", server.ReadinessService, notServing)
+	expect("initial", "", notServing)
+
+	probe.set(nil)
+	reporter.Check(ctx)
+	expect("ready", server.LivenessService, serving)
+	expect("ready", server.ReadinessService, serving)
+	expect("ready", "", serving)
+	expect("ready", "explore.ExploreService", serving)
+
+	probe.set(errors.New("migrations pending"))
+	reporter.Check(ctx)
+	expect("not ready", server.LivenessService, serving)
+	expect("not ready", server.ReadinessService, notServing)
+	expect("not ready", "explore.ExploreService", notServing)
+
+	probe.set(nil)
+	reporter.Check(ctx)
+	reporter.Shutdown()
+	reporter.Check(ctx)
+	expect("shutdown", server.LivenessService, notServing)
+	expect("shutdown", server.ReadinessService, notServing)
+	expect("shutdown", "", notServing)
+}
@@ -0,0 +1,154 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"explore_service/internal/server"
+	"explore_service/internal/storage"
+	explorepb "explore_service/proto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+)
+
+// histogramCount returns the number of observations of the histogram
+// named name with the given label values in reg.
+func histogramCount(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) uint64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather returned error: %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if labels[l.GetName()] != l.GetValue() {
+					continue metrics
+				}
+			}
+			return m.GetHistogram().GetSampleCount()
+		}
+	}
+	return 0
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	intercept := server.MetricsInterceptor(reg)
+	srv := server.NewExploreServer(storage.NewMemoryStore(), 10)
+	const method = "/explore.ExploreService/PutDecision"
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.PutDecision(ctx, req.(*explorepb.PutDecisionRequest))
+	}
+	for _, req := range []*explorepb.PutDecisionRequest{
+		{ActorUserId: "actor1", RecipientUserId: "user1", LikedRecipient: true},
+		{ActorUserId: "actor1", RecipientUserId: "user2"},
+		{ActorUserId: "actor1", RecipientUserId: "actor1"},
+	} {
+		_, _ = intercept(ctx, req, info, handler)
+	}
+	const want = `
+# HELP explore_grpc_requests_total Unary gRPC requests handled, by method and status code.
+# TYPE explore_grpc_requests_total counter
+explore_grpc_requests_total{code="InvalidArgument",method="/explore.ExploreService/PutDecision"} 1
+explore_grpc_requests_total{code="OK",method="/explore.ExploreService/PutDecision"} 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "explore_grpc_requests_total"); err != nil {
+		t.Error(err)
+	}
+	if n := histogramCount(t, reg, "explore_grpc_request_duration_seconds", map[string]string{"method": method}); n != 3 {
+		t.Errorf("expected 3 latency observations, got %d", n)
+	}
+}
+
+func TestQueryTracer(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	tracer := storage.NewQueryTracer(reg)
+	for _, err := range []error{nil, nil, errors.New("boom")} {
+		traced := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1;"})
+		tracer.TraceQueryEnd(traced, nil, pgx.TraceQueryEndData{Err: err})
+	}
+	// Queries not run by the storage package are labelled "other".
+	if n := histogramCount(t, reg, "explore_db_query_duration_seconds", map[string]string{"operation": "other", "result": "ok"}); n != 2 {
+		t.Errorf("expected 2 successful queries, got %d", n)
+	}
+	if n := histogramCount(t, reg, "explore_db_query_duration_seconds", map[string]string{"operation": "other", "result": "error"}); n != 1 {
+		t.Errorf("expected 1 failed query, got %d", n)
+	}
+
+	t.Run("StoreOperations", func(t *testing.T) {
+		pool, cleanup := startPostgres(ctx, t)
+		defer cleanup()
+		cfg := pool.Config()
+		reg := prometheus.NewRegistry()
+		cfg.ConnConfig.Tracer = storage.NewQueryTracer(reg)
+		traced, err := pgxpool.NewWithConfig(ctx, cfg)
+		if err != nil {
+			t.Fatalf("NewWithConfig returned error: %v", err)
+		}
+		defer traced.Close()
+		store, err := storage.NewStore(ctx, traced)
+		if err != nil {
+			t.Fatalf("NewStore returned error: %v", err)
+		}
+		id := userIDs(t)
+		if _, err := store.PutDecision(ctx, id("a"), id("b"), storage.Like); err != nil {
+			t.Fatalf("PutDecision returned error: %v", err)
+		}
+		if _, err := store.GetLikeSummary(ctx, id("b")); err != nil {
+			t.Fatalf("GetLikeSummary returned error: %v", err)
+		}
+		// Queries are labelled with the Store method that ran them,
+		// including those run by its helpers.
+		for _, operation := range []string{"Migrate", "PutDecision", "GetLikeSummary"} {
+			if n := histogramCount(t, reg, "explore_db_query_duration_seconds", map[string]string{"operation": operation, "result": "ok"}); n == 0 {
+				t.Errorf("expected queries labelled %s", operation)
+			}
+		}
+		if n := histogramCount(t, reg, "explore_db_query_duration_seconds", map[string]string{"operation": "other", "result": "ok"}); n != 0 {
+			t.Errorf("expected every query to be labelled, got %d labelled other", n)
+		}
+	})
+}
+
+func TestPoolCollector(t *testing.T) {
+	// The pool connects lazily, so no database is needed to read its
+	// statistics.
+	cfg, err := pgxpool.ParseConfig("postgres://user@127.0.0.1:1/explore?pool_max_conns=7")
+	if err != nil {
+		t.Fatalf("ParseConfig returned error: %v", err)
+	}
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig returned error: %v", err)
+	}
+	defer pool.Close()
+	collector := storage.NewPoolCollector(pool)
+	if n := testutil.CollectAndCount(collector); n != 9 {
+		t.Errorf("expected 9 pool metrics, got %d", n)
+	}
+	const want = `
+# HELP explore_db_pool_max_connections Maximum size of the pool.
+# TYPE explore_db_pool_max_connections gauge
+explore_db_pool_max_connections 7
+# HELP explore_db_pool_acquired_connections Connections currently acquired from the pool.
+# TYPE explore_db_pool_acquired_connections gauge
+explore_db_pool_acquired_connections 0
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(want), "explore_db_pool_max_connections", "explore_db_pool_acquired_connections"); err != nil {
+		t.Error(err)
+	}
+}
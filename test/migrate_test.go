@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"explore_service/internal/storage"
+)
+
+// TestMigrations applies, reverts and re-applies the embedded
+// migrations, including from several concurrent migrators.
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	pool, cleanup := startPostgres(ctx, t)
+	defer cleanup()
+	m, err := storage.NewMigrator(pool)
+	if err != nil {
+		t.Fatalf("NewMigrator returned error: %v", err)
+	}
+	// Replicas starting together must not race each other.
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := m.Up(ctx)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent Up returned error: %v", err)
+		}
+	}
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	if len(statuses) == 0 {
+		t.Fatalf("expected embedded migrations")
+	}
+	for _, st := range statuses {
+		if st.AppliedAt == nil {
+			t.Errorf("migration %d_%s not applied", st.Version, st.Name)
+		}
+	}
+	if n, err := m.Up(ctx); err != nil || n != 0 {
+		t.Errorf("expected Up to be a no-op, applied %d (err %v)", n, err)
+	}
+	store, err := storage.NewStore(ctx, pool, storage.WithoutMigrations())
+	if err != nil {
+		t.Fatalf("NewStore returned error: %v", err)
+	}
+	if err := store.Ready(ctx); err != nil {
+		t.Errorf("expected the migrated store to be ready, got %v", err)
+	}
+	// Revert everything and apply it again.
+	n, err := m.Down(ctx, len(statuses))
+	if err != nil {
+		t.Fatalf("Down returned error: %v", err)
+	}
+	if n != len(statuses) {
+		t.Errorf("expected %d migrations reverted, got %d", len(statuses), n)
+	}
+	if pending, err := m.Pending(ctx); err != nil || pending != len(statuses) {
+		t.Errorf("expected %d pending migrations, got %d (err %v)", len(statuses), pending, err)
+	}
+	if err := store.Ready(ctx); err == nil {
+		t.Errorf("expected the store not to be ready with pending migrations")
+	}
+	if n, err := m.Up(ctx); err != nil || n != len(statuses) {
+		t.Fatalf("expected %d migrations re-applied, got %d (err %v)", len(statuses), n, err)
+	}
+	// The schema must be usable after the round trip.
+	if err := store.Ready(ctx); err != nil {
+		t.Errorf("expected the store to be ready again, got %v", err)
+	}
+	if _, err := store.PutDecision(ctx, "actor1", "user1", storage.Like); err != nil {
+		t.Errorf("PutDecision after migrations returned error: %v", err)
+	}
+}
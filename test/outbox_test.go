@@ -0,0 +1,262 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"explore_service/internal/outbox"
+	"explore_service/internal/storage"
+)
+
+// recordingPublisher remembers every event it is given.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []outbox.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e outbox.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	return nil
+}
+
+// received returns the recorded events whose actor has the given
+// prefix, formatted as "Type actor->recipient".
+func (p *recordingPublisher) received(prefix string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var got []string
+	for _, e := range p.events {
+		if strings.HasPrefix(e.ActorID, prefix) {
+			got = append(got, fmt.Sprintf("%s %s->%s", e.Type, strings.TrimPrefix(e.ActorID, prefix), strings.TrimPrefix(e.RecipientID, prefix)))
+		}
+	}
+	return got
+}
+
+// drain empties the outbox into pub.
+func drain(t *testing.T, source outbox.Source, pub outbox.EventPublisher) {
+	t.Helper()
+	for {
+		n, err := source.DrainOutbox(context.Background(), 100, pub.Publish)
+		if err != nil {
+			t.Fatalf("DrainOutbox returned error: %v", err)
+		}
+		if n == 0 {
+			return
+		}
+	}
+}
+
+// TestOutbox checks the events PutDecision records and that a failed
+// publish leaves the event for redelivery.
+func TestOutbox(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := b.newStore(t)
+			source, ok := store.(outbox.Source)
+			if !ok {
+				t.Fatalf("%T does not implement outbox.Source", store)
+			}
+			id := userIDs(t)
+			prefix := id("")
+			put := func(actor, recipient string, liked bool) {
+				t.Helper()
+				if _, err := store.PutDecision(ctx, id(actor), id(recipient), likeOrPass(liked)); err != nil {
+					t.Fatalf("PutDecision returned error: %v", err)
+				}
+			}
+			drain(t, source, &recordingPublisher{})
+
+			pub := &recordingPublisher{}
+			put("a", "b", true)
+			put("a", "b", true) // already liked: no new event
+			put("b", "a", true)
+			put("c", "b", false)
+			put("b", "a", false)
+			put("b", "a", false) // already removed: no new event
+			drain(t, source, pub)
+			want := []string{
+				"LikeReceived a->b",
+				"LikeReceived b->a",
+				"MatchCreated b->a",
+				"MatchRemoved b->a",
+			}
+			if got := pub.received(prefix); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected events %v, got %v", want, got)
+			}
+			for i := 1; i < len(pub.events); i++ {
+				if pub.events[i].ID <= pub.events[i-1].ID {
+					t.Errorf("expected increasing event IDs, got %d after %d", pub.events[i].ID, pub.events[i-1].ID)
+				}
+			}
+
+			// Rewinding a like that made a match withdraws both.
+			put("d", "e", true)
+			put("e", "d", true)
+			if _, err := store.RewindDecision(ctx, id("e"), time.Minute); err != nil {
+				t.Fatalf("RewindDecision returned error: %v", err)
+			}
+			pub = &recordingPublisher{}
+			drain(t, source, pub)
+			want = []string{
+				"LikeReceived d->e",
+				"LikeReceived e->d",
+				"MatchCreated e->d",
+				"DecisionRewound e->d",
+				"MatchRemoved e->d",
+			}
+			if got := pub.received(prefix); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected events %v after a rewind, got %v", want, got)
+			}
+
+			// Upgrading a like to a super like notifies again.
+			put("h", "i", true)
+			if _, err := store.PutDecision(ctx, id("h"), id("i"), storage.SuperLike); err != nil {
+				t.Fatalf("PutDecision returned error: %v", err)
+			}
+			pub = &recordingPublisher{}
+			drain(t, source, pub)
+			var superLikes []bool
+			for _, e := range pub.events {
+				superLikes = append(superLikes, e.SuperLike)
+			}
+			if got, want := pub.received(prefix), []string{"LikeReceived h->i", "LikeReceived h->i"}; fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected events %v for a super like, got %v", want, got)
+			} else if fmt.Sprint(superLikes) != fmt.Sprint([]bool{false, true}) {
+				t.Errorf("expected only the second event to be a super like, got %v", superLikes)
+			}
+
+			// A block removes the match and hides a later like; lifting
+			// it restores the match.
+			put("j", "k", true)
+			put("k", "j", true)
+			if err := store.BlockUser(ctx, id("j"), id("k")); err != nil {
+				t.Fatalf("BlockUser returned error: %v", err)
+			}
+			put("l", "j", true)
+			if err := store.BlockUser(ctx, id("j"), id("l")); err != nil {
+				t.Fatalf("BlockUser returned error: %v", err)
+			}
+			put("l", "j", false)
+			put("l", "j", true) // hidden by the block: no new event
+			if err := store.UnblockUser(ctx, id("j"), id("k")); err != nil {
+				t.Fatalf("UnblockUser returned error: %v", err)
+			}
+			pub = &recordingPublisher{}
+			drain(t, source, pub)
+			want = []string{
+				"LikeReceived j->k",
+				"LikeReceived k->j",
+				"MatchCreated k->j",
+				"MatchRemoved j->k",
+				"LikeReceived l->j",
+				"MatchCreated j->k",
+			}
+			if got := pub.received(prefix); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected events %v around a block, got %v", want, got)
+			}
+
+			// A failed publish stops the batch and the event is
+			// delivered again on the next drain.
+			put("f", "g", true)
+			put("g", "f", true)
+			pub = &recordingPublisher{}
+			n, err := source.DrainOutbox(ctx, 100, func(ctx context.Context, e outbox.Event) error {
+				if e.Type == outbox.MatchCreated {
+					return errors.New("broker unavailable")
+				}
+				return pub.Publish(ctx, e)
+			})
+			if err == nil || n != 2 {
+				t.Fatalf("expected 2 events and an error, got %d and %v", n, err)
+			}
+			drain(t, source, pub)
+			want = []string{"LikeReceived f->g", "LikeReceived g->f", "MatchCreated g->f"}
+			if got := pub.received(prefix); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected events %v after redelivery, got %v", want, got)
+			}
+		})
+	}
+}
+
+// TestRelay checks that a running relay delivers events to a file
+// publisher as NATS-style JSON lines.
+func TestRelay(t *testing.T) {
+	store := storage.NewMemoryStore()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	pub, err := outbox.NewFilePublisher(path)
+	if err != nil {
+		t.Fatalf("NewFilePublisher returned error: %v", err)
+	}
+	defer pub.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		outbox.NewRelay(store, pub, 10*time.Millisecond, 2).Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	for _, d := range []struct {
+		actor, recipient string
+	}{{"a", "b"}, {"b", "a"}, {"c", "b"}} {
+		if _, err := store.PutDecision(ctx, d.actor, d.recipient, storage.Like); err != nil {
+			t.Fatalf("PutDecision returned error: %v", err)
+		}
+	}
+	want := []string{
+		"explore.events.like_received a->b",
+		"explore.events.like_received b->a",
+		"explore.events.match_created b->a",
+		"explore.events.like_received c->b",
+	}
+	var got []string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		got = readEventFile(t, path)
+		if len(got) >= len(want) {
+			break
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected events %v, got %v", want, got)
+	}
+}
+
+// readEventFile returns the lines written by a FilePublisher formatted
+// as "subject actor->recipient".
+func readEventFile(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open event file: %v", err)
+	}
+	defer f.Close()
+	var got []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec struct {
+			Subject string       `json:"subject"`
+			Data    outbox.Event `json:"data"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid event line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, fmt.Sprintf("%s %s->%s", rec.Subject, rec.Data.ActorID, rec.Data.RecipientID))
+	}
+	return got
+}
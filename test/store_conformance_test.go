@@ -0,0 +1,1563 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"explore_service/internal/storage"
+	"explore_service/internal/sweeper"
+)
+
+// userIDs returns a function that namespaces user identifiers to the
+// running test.  The prefix is unique per run so that suites sharing
+// an external database via TEST_PG_DSN do not see each other's rows.
+func userIDs(t *testing.T) func(string) string {
+	prefix := fmt.Sprintf("%s/%d/", t.Name(), time.Now().UnixNano())
+	return func(name string) string { return prefix + name }
+}
+
+// likeOrPass returns Like if liked is true and Pass otherwise.
+func likeOrPass(liked bool) storage.DecisionType {
+	if liked {
+		return storage.Like
+	}
+	return storage.Pass
+}
+
+// TestDecisionStoreConformance runs the shared DecisionStore suite
+// against every backend so that MemoryStore and Store stay
+// interchangeable.
+func TestDecisionStoreConformance(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			runDecisionStoreConformance(t, b.newStore(t))
+		})
+	}
+}
+
+func runDecisionStoreConformance(t *testing.T, store storage.DecisionStore) {
+	ctx := context.Background()
+	put := func(t *testing.T, actor, recipient string, liked bool) bool {
+		t.Helper()
+		mutual, err := store.PutDecision(ctx, actor, recipient, likeOrPass(liked))
+		if err != nil {
+			t.Fatalf("PutDecision(%s, %s, %v) returned error: %v", actor, recipient, liked, err)
+		}
+		return mutual
+	}
+	actorIDs := func(likers []storage.Liker) []string {
+		ids := make([]string, len(likers))
+		for i, l := range likers {
+			ids[i] = l.ActorID
+		}
+		return ids
+	}
+
+	t.Run("MutualLikes", func(t *testing.T) {
+		id := userIDs(t)
+		if put(t, id("a"), id("b"), true) {
+			t.Errorf("expected no mutual like on first like")
+		}
+		if !put(t, id("b"), id("a"), true) {
+			t.Errorf("expected mutual like after reciprocal like")
+		}
+		if put(t, id("a"), id("b"), false) {
+			t.Errorf("expected no mutual like after pass")
+		}
+		if put(t, id("b"), id("a"), true) {
+			t.Errorf("expected no mutual like when the other side passed")
+		}
+	})
+
+	t.Run("PassOverwritesLike", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("a"), id("r"), true)
+		put(t, id("a"), id("r"), false)
+		count, err := store.CountLikedYou(ctx, id("r"), storage.TimeRange{})
+		if err != nil {
+			t.Fatalf("CountLikedYou returned error: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("expected count 0 after pass, got %d", count)
+		}
+	})
+
+	t.Run("ListLikedYouOrdering", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("a1"), id("r"), true)
+		put(t, id("a2"), id("r"), true)
+		put(t, id("a3"), id("r"), false)
+		put(t, id("a4"), id("r"), true)
+		// Re-liking moves a1 to the front.
+		put(t, id("a1"), id("r"), true)
+		likers, next, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 10, storage.NewestFirst)
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		want := []string{id("a1"), id("a4"), id("a2")}
+		if got := actorIDs(likers); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected likers %v, got %v", want, got)
+		}
+		if next != nil {
+			t.Errorf("expected no next token, got %q", *next)
+		}
+		for _, l := range likers {
+			if l.Unix == 0 {
+				t.Errorf("expected a timestamp for %s", l.ActorID)
+			}
+		}
+	})
+
+	t.Run("ListLikedYouPagination", func(t *testing.T) {
+		id := userIDs(t)
+		var want []string
+		for i := 0; i < 5; i++ {
+			actor := id(fmt.Sprintf("a%d", i))
+			put(t, actor, id("r"), true)
+			want = append([]string{actor}, want...)
+		}
+		var got []string
+		var after *storage.Cursor
+		pages := 0
+		for {
+			likers, next, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, after, 2, storage.NewestFirst)
+			if err != nil {
+				t.Fatalf("ListLikedYou returned error: %v", err)
+			}
+			pages++
+			got = append(got, actorIDs(likers)...)
+			if next == nil {
+				break
+			}
+			after = next
+		}
+		if pages != 3 {
+			t.Errorf("expected 3 pages, got %d", pages)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected likers %v, got %v", want, got)
+		}
+	})
+
+	t.Run("PaginationStableUnderUpdates", func(t *testing.T) {
+		id := userIDs(t)
+		for i := 0; i < 4; i++ {
+			put(t, id(fmt.Sprintf("a%d", i)), id("r"), true)
+		}
+		first, next, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 2, storage.NewestFirst)
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		if next == nil {
+			t.Fatalf("expected a next cursor after the first page")
+		}
+		// a0 re-likes and a3, already seen, passes.  Neither change
+		// may shift the remaining page.
+		put(t, id("a0"), id("r"), true)
+		put(t, id("a3"), id("r"), false)
+		second, next, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, next, 2, storage.NewestFirst)
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		if want := []string{id("a3"), id("a2")}; fmt.Sprint(actorIDs(first)) != fmt.Sprint(want) {
+			t.Errorf("expected first page %v, got %v", want, actorIDs(first))
+		}
+		if want := []string{id("a1")}; fmt.Sprint(actorIDs(second)) != fmt.Sprint(want) {
+			t.Errorf("expected second page %v, got %v", want, actorIDs(second))
+		}
+		if next != nil {
+			t.Errorf("expected no cursor after the last page")
+		}
+	})
+
+	t.Run("OldestFirst", func(t *testing.T) {
+		id := userIDs(t)
+		var want []string
+		for i := 0; i < 5; i++ {
+			actor := id(fmt.Sprintf("a%d", i))
+			put(t, actor, id("r"), true)
+			want = append(want, actor)
+		}
+		// a1 is liked back, which hides it from ListNewLikedYou.
+		put(t, id("r"), id("a1"), true)
+		lists := map[string]func(*storage.Cursor) ([]storage.Liker, *storage.Cursor, error){
+			"ListLikedYou": func(after *storage.Cursor) ([]storage.Liker, *storage.Cursor, error) {
+				return store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, after, 2, storage.OldestFirst)
+			},
+			"ListNewLikedYou": func(after *storage.Cursor) ([]storage.Liker, *storage.Cursor, error) {
+				return store.ListNewLikedYou(ctx, id("r"), storage.TimeRange{}, after, 2, storage.OldestFirst)
+			},
+		}
+		for name, list := range lists {
+			var got []string
+			var after *storage.Cursor
+			for {
+				likers, next, err := list(after)
+				if err != nil {
+					t.Fatalf("%s returned error: %v", name, err)
+				}
+				got = append(got, actorIDs(likers)...)
+				if next == nil {
+					break
+				}
+				if next.Order != storage.OldestFirst {
+					t.Errorf("%s: expected the cursor to record OldestFirst", name)
+				}
+				parsed, err := storage.ParseCursor(next.Encode())
+				if err != nil {
+					t.Fatalf("ParseCursor returned error: %v", err)
+				}
+				after = &parsed
+			}
+			expected := want
+			if name == "ListNewLikedYou" {
+				expected = []string{want[0], want[2], want[3], want[4]}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(expected) {
+				t.Errorf("%s: expected likers %v, got %v", name, expected, got)
+			}
+		}
+		// A cursor cannot switch the order mid-listing.
+		_, next, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 2, storage.OldestFirst)
+		if err != nil || next == nil {
+			t.Fatalf("expected a next cursor, got %v (err %v)", next, err)
+		}
+		if _, _, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, next, 2, storage.NewestFirst); !errors.Is(err, storage.ErrInvalidCursor) {
+			t.Errorf("expected ErrInvalidCursor for a cursor in another order, got %v", err)
+		}
+		if _, _, err := store.ListMatches(ctx, id("r"), next, 2); !errors.Is(err, storage.ErrInvalidCursor) {
+			t.Errorf("expected ListMatches to reject an oldest-first cursor, got %v", err)
+		}
+	})
+
+	t.Run("TimeRange", func(t *testing.T) {
+		id := userIDs(t)
+		for i := 0; i < 5; i++ {
+			put(t, id(fmt.Sprintf("a%d", i)), id("r"), true)
+		}
+		put(t, id("r"), id("a2"), true)
+		// Read the exact like times back from single-row pages: the
+		// next cursor holds the position of the row just returned.
+		var at []time.Time
+		var after *storage.Cursor
+		for i := 0; i < 4; i++ {
+			_, next, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, after, 1, storage.OldestFirst)
+			if err != nil || next == nil {
+				t.Fatalf("expected a next cursor, got %v (err %v)", next, err)
+			}
+			at = append(at, next.Timestamp)
+			after = next
+		}
+		cases := []struct {
+			name      string
+			window    storage.TimeRange
+			want, new []string
+		}{
+			{"closed", storage.TimeRange{Since: at[1], Until: at[3]}, []string{"a1", "a2"}, []string{"a1"}},
+			{"since only", storage.TimeRange{Since: at[3]}, []string{"a3", "a4"}, []string{"a3", "a4"}},
+			{"until only", storage.TimeRange{Until: at[1]}, []string{"a0"}, []string{"a0"}},
+			{"empty", storage.TimeRange{Since: at[2], Until: at[2]}, nil, nil},
+			{"unbounded", storage.TimeRange{}, []string{"a0", "a1", "a2", "a3", "a4"}, []string{"a0", "a1", "a3", "a4"}},
+		}
+		for _, c := range cases {
+			var want, wantNew []string
+			for _, a := range c.want {
+				want = append(want, id(a))
+			}
+			for _, a := range c.new {
+				wantNew = append(wantNew, id(a))
+			}
+			likers, _, err := store.ListLikedYou(ctx, id("r"), c.window, nil, 10, storage.OldestFirst)
+			if err != nil {
+				t.Fatalf("ListLikedYou returned error: %v", err)
+			}
+			if got := actorIDs(likers); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("%s: expected likers %v, got %v", c.name, want, got)
+			}
+			likers, _, err = store.ListNewLikedYou(ctx, id("r"), c.window, nil, 10, storage.OldestFirst)
+			if err != nil {
+				t.Fatalf("ListNewLikedYou returned error: %v", err)
+			}
+			if got := actorIDs(likers); fmt.Sprint(got) != fmt.Sprint(wantNew) {
+				t.Errorf("%s: expected new likers %v, got %v", c.name, wantNew, got)
+			}
+			count, err := store.CountLikedYou(ctx, id("r"), c.window)
+			if err != nil {
+				t.Fatalf("CountLikedYou returned error: %v", err)
+			}
+			if count != uint64(len(want)) {
+				t.Errorf("%s: expected count %d, got %d", c.name, len(want), count)
+			}
+			count, err = store.CountNewLikedYou(ctx, id("r"), c.window)
+			if err != nil {
+				t.Fatalf("CountNewLikedYou returned error: %v", err)
+			}
+			if count != uint64(len(wantNew)) {
+				t.Errorf("%s: expected new count %d, got %d", c.name, len(wantNew), count)
+			}
+		}
+		// Pagination within a window stays inside it.
+		likers, next, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{Until: at[3]}, nil, 2, storage.NewestFirst)
+		if err != nil || next == nil {
+			t.Fatalf("expected a next cursor, got %v (err %v)", next, err)
+		}
+		rest, next, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{Until: at[3]}, next, 2, storage.NewestFirst)
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		if got, want := actorIDs(append(likers, rest...)), []string{id("a2"), id("a1"), id("a0")}; fmt.Sprint(got) != fmt.Sprint(want) || next != nil {
+			t.Errorf("expected likers %v on two pages, got %v", want, got)
+		}
+	})
+
+	t.Run("CursorRoundTrip", func(t *testing.T) {
+		id := userIDs(t)
+		for i := 0; i < 3; i++ {
+			put(t, id(fmt.Sprintf("a%d", i)), id("r"), true)
+		}
+		_, next, err := store.ListNewLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 1, storage.NewestFirst)
+		if err != nil || next == nil {
+			t.Fatalf("expected a next cursor, got %v (err %v)", next, err)
+		}
+		parsed, err := storage.ParseCursor(next.Encode())
+		if err != nil {
+			t.Fatalf("ParseCursor returned error: %v", err)
+		}
+		likers, _, err := store.ListNewLikedYou(ctx, id("r"), storage.TimeRange{}, &parsed, 5, storage.NewestFirst)
+		if err != nil {
+			t.Fatalf("ListNewLikedYou returned error: %v", err)
+		}
+		if want := []string{id("a1"), id("a0")}; fmt.Sprint(actorIDs(likers)) != fmt.Sprint(want) {
+			t.Errorf("expected %v after the decoded cursor, got %v", want, actorIDs(likers))
+		}
+	})
+
+	t.Run("ListNewLikedYouExcludesMutual", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("a1"), id("r"), true)
+		put(t, id("a2"), id("r"), true)
+		put(t, id("r"), id("a1"), true)
+		// A pass from the recipient does not hide the liker.
+		put(t, id("a3"), id("r"), true)
+		put(t, id("r"), id("a3"), false)
+		likers, _, err := store.ListNewLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 10, storage.NewestFirst)
+		if err != nil {
+			t.Fatalf("ListNewLikedYou returned error: %v", err)
+		}
+		want := []string{id("a3"), id("a2")}
+		if got := actorIDs(likers); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected new likers %v, got %v", want, got)
+		}
+	})
+
+	t.Run("CountLikedYou", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("a1"), id("r"), true)
+		put(t, id("a2"), id("r"), true)
+		put(t, id("a3"), id("r"), false)
+		put(t, id("r"), id("a1"), true)
+		count, err := store.CountLikedYou(ctx, id("r"), storage.TimeRange{})
+		if err != nil {
+			t.Fatalf("CountLikedYou returned error: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected count 2, got %d", count)
+		}
+	})
+
+	t.Run("LikeSummary", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("a1"), id("r"), true)
+		put(t, id("a2"), id("r"), true)
+		put(t, id("a3"), id("r"), true)
+		put(t, id("a4"), id("r"), false)
+		put(t, id("r"), id("a1"), true)
+		put(t, id("r"), id("a3"), false)
+		put(t, id("r"), id("a5"), true)
+		put(t, id("a5"), id("r"), true)
+		count, err := store.CountNewLikedYou(ctx, id("r"), storage.TimeRange{})
+		if err != nil {
+			t.Fatalf("CountNewLikedYou returned error: %v", err)
+		}
+		likers, _, err := store.ListNewLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 10, storage.NewestFirst)
+		if err != nil {
+			t.Fatalf("ListNewLikedYou returned error: %v", err)
+		}
+		if count != 2 || len(likers) != 2 {
+			t.Errorf("expected 2 new likers counted and listed, got %d and %v", count, actorIDs(likers))
+		}
+		sum, err := store.GetLikeSummary(ctx, id("r"))
+		if err != nil {
+			t.Fatalf("GetLikeSummary returned error: %v", err)
+		}
+		if want := (storage.LikeSummary{Likes: 4, NewLikes: 2, Matches: 2}); sum != want {
+			t.Errorf("expected summary %+v, got %+v", want, sum)
+		}
+		sum, err = store.GetLikeSummary(ctx, id("nobody"))
+		if err != nil {
+			t.Fatalf("GetLikeSummary returned error: %v", err)
+		}
+		if sum != (storage.LikeSummary{}) {
+			t.Errorf("expected an empty summary, got %+v", sum)
+		}
+	})
+
+	t.Run("Matches", func(t *testing.T) {
+		id := userIDs(t)
+		matchIDs := func(t *testing.T, user string) []string {
+			t.Helper()
+			matches, _, err := store.ListMatches(ctx, user, nil, 10)
+			if err != nil {
+				t.Fatalf("ListMatches returned error: %v", err)
+			}
+			ids := make([]string, len(matches))
+			for i, m := range matches {
+				ids[i] = m.UserID
+				if m.Unix == 0 {
+					t.Errorf("expected a timestamp for the match with %s", m.UserID)
+				}
+			}
+			return ids
+		}
+		put(t, id("a"), id("b"), true)
+		if got := matchIDs(t, id("a")); len(got) != 0 {
+			t.Fatalf("expected no matches before the like is mutual, got %v", got)
+		}
+		put(t, id("b"), id("a"), true)
+		put(t, id("c"), id("a"), true)
+		put(t, id("a"), id("c"), true)
+		// Liking again keeps the existing match and its position.
+		put(t, id("a"), id("b"), true)
+		if want, got := []string{id("c"), id("b")}, matchIDs(t, id("a")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected matches %v, got %v", want, got)
+		}
+		if want, got := []string{id("a")}, matchIDs(t, id("b")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected matches %v for b, got %v", want, got)
+		}
+		// A pass from either side removes the match for both users.
+		put(t, id("b"), id("a"), false)
+		if want, got := []string{id("c")}, matchIDs(t, id("a")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected matches %v after pass, got %v", want, got)
+		}
+		if got := matchIDs(t, id("b")); len(got) != 0 {
+			t.Errorf("expected no matches for b after pass, got %v", got)
+		}
+		put(t, id("a"), id("c"), false)
+		if got := matchIDs(t, id("c")); len(got) != 0 {
+			t.Errorf("expected no matches for c after pass, got %v", got)
+		}
+	})
+
+	t.Run("MatchesPagination", func(t *testing.T) {
+		id := userIDs(t)
+		var want []string
+		for i := 0; i < 3; i++ {
+			other := id(fmt.Sprintf("u%d", i))
+			put(t, other, id("me"), true)
+			put(t, id("me"), other, true)
+			want = append([]string{other}, want...)
+		}
+		var got []string
+		var after *storage.Cursor
+		for {
+			matches, next, err := store.ListMatches(ctx, id("me"), after, 2)
+			if err != nil {
+				t.Fatalf("ListMatches returned error: %v", err)
+			}
+			for _, m := range matches {
+				got = append(got, m.UserID)
+			}
+			if next == nil {
+				break
+			}
+			after = next
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected matches %v, got %v", want, got)
+		}
+	})
+
+	t.Run("DecisionHistory", func(t *testing.T) {
+		id := userIDs(t)
+		for _, liked := range []bool{true, false, true, true} {
+			put(t, id("a"), id("b"), liked)
+		}
+		put(t, id("b"), id("a"), true)
+		events, err := store.GetDecisionHistory(ctx, id("a"), id("b"))
+		if err != nil {
+			t.Fatalf("GetDecisionHistory returned error: %v", err)
+		}
+		var got []bool
+		for i, e := range events {
+			got = append(got, e.Liked)
+			if e.Unix == 0 || (i > 0 && e.Unix < events[i-1].Unix) {
+				t.Errorf("expected non-decreasing timestamps, got %v", events)
+			}
+		}
+		if want := []bool{true, false, true, true}; fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected history %v, got %v", want, got)
+		}
+		events, err = store.GetDecisionHistory(ctx, id("b"), id("c"))
+		if err != nil {
+			t.Fatalf("GetDecisionHistory returned error: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("expected no history for an undecided pair, got %v", events)
+		}
+	})
+
+	t.Run("GetDecision", func(t *testing.T) {
+		id := userIDs(t)
+		if _, ok, err := store.GetDecision(ctx, id("a"), id("b")); err != nil || ok {
+			t.Fatalf("expected no decision before deciding, got %v and %v", ok, err)
+		}
+		put(t, id("a"), id("b"), true)
+		put(t, id("a"), id("b"), false)
+		d, ok, err := store.GetDecision(ctx, id("a"), id("b"))
+		if err != nil || !ok {
+			t.Fatalf("expected a decision, got %v and %v", ok, err)
+		}
+		if d.RecipientID != id("b") || d.Liked || d.Unix == 0 {
+			t.Errorf("expected a timestamped pass of b, got %+v", d)
+		}
+		// Decisions are directed.
+		if _, ok, err := store.GetDecision(ctx, id("b"), id("a")); err != nil || ok {
+			t.Errorf("expected no decision by b, got %v and %v", ok, err)
+		}
+	})
+
+	t.Run("ListMyDecisions", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("me"), id("r1"), true)
+		put(t, id("me"), id("r2"), false)
+		put(t, id("me"), id("r3"), true)
+		put(t, id("me"), id("r4"), false)
+		// Updating a decision moves it to the front.
+		put(t, id("me"), id("r1"), true)
+		put(t, id("other"), id("r2"), true)
+		list := func(t *testing.T, filter storage.DecisionFilter) []string {
+			t.Helper()
+			var got []string
+			var after *storage.Cursor
+			for {
+				decisions, next, err := store.ListMyDecisions(ctx, id("me"), filter, after, 2)
+				if err != nil {
+					t.Fatalf("ListMyDecisions returned error: %v", err)
+				}
+				for _, d := range decisions {
+					if d.Unix == 0 {
+						t.Errorf("expected a timestamp for %s", d.RecipientID)
+					}
+					got = append(got, fmt.Sprintf("%s:%v", d.RecipientID, d.Liked))
+				}
+				if next == nil {
+					return got
+				}
+				after = next
+			}
+		}
+		cases := []struct {
+			filter storage.DecisionFilter
+			want   []string
+		}{
+			{storage.AllDecisions, []string{id("r1") + ":true", id("r4") + ":false", id("r3") + ":true", id("r2") + ":false"}},
+			{storage.LikedDecisions, []string{id("r1") + ":true", id("r3") + ":true"}},
+			{storage.PassedDecisions, []string{id("r4") + ":false", id("r2") + ":false"}},
+		}
+		for _, c := range cases {
+			if got := list(t, c.filter); fmt.Sprint(got) != fmt.Sprint(c.want) {
+				t.Errorf("filter %d: expected %v, got %v", c.filter, c.want, got)
+			}
+		}
+	})
+
+	t.Run("RewindDecision", func(t *testing.T) {
+		id := userIDs(t)
+		rewind := func(t *testing.T, actor string) storage.Rewind {
+			t.Helper()
+			r, err := store.RewindDecision(ctx, id(actor), time.Minute)
+			if err != nil {
+				t.Fatalf("RewindDecision returned error: %v", err)
+			}
+			return r
+		}
+		decided := func(t *testing.T, actor, recipient string) *storage.StoredDecision {
+			t.Helper()
+			d, ok, err := store.GetDecision(ctx, id(actor), id(recipient))
+			if err != nil {
+				t.Fatalf("GetDecision returned error: %v", err)
+			}
+			if !ok {
+				return nil
+			}
+			return &d
+		}
+		matched := func(t *testing.T, a, b string) bool {
+			t.Helper()
+			matches, _, err := store.ListMatches(ctx, id(a), nil, 10)
+			if err != nil {
+				t.Fatalf("ListMatches returned error: %v", err)
+			}
+			return len(matches) == 1 && matches[0].UserID == id(b)
+		}
+		likerIDs := func(t *testing.T, recipient string) []string {
+			t.Helper()
+			likers, _, err := store.ListLikedYou(ctx, id(recipient), storage.TimeRange{}, nil, 10, storage.NewestFirst)
+			if err != nil {
+				t.Fatalf("ListLikedYou returned error: %v", err)
+			}
+			return actorIDs(likers)
+		}
+		if _, err := store.RewindDecision(ctx, id("a"), time.Minute); !errors.Is(err, storage.ErrNothingToRewind) {
+			t.Fatalf("expected ErrNothingToRewind without decisions, got %v", err)
+		}
+
+		// Rewinding a first decision deletes it and tears down the
+		// match it created.
+		put(t, id("b"), id("a"), true)
+		put(t, id("a"), id("b"), true)
+		put(t, id("a"), id("c"), false)
+		if r := rewind(t, "a"); r.RecipientID != id("c") || r.Liked || r.Restored != nil {
+			t.Errorf("expected the pass of c to be deleted, got %+v", r)
+		}
+		if d := decided(t, "a", "c"); d != nil {
+			t.Errorf("expected no decision on c after rewinding, got %+v", d)
+		}
+		if r := rewind(t, "a"); r.RecipientID != id("b") || !r.Liked || r.Restored != nil {
+			t.Errorf("expected the like of b to be deleted, got %+v", r)
+		}
+		if matched(t, "a", "b") || matched(t, "b", "a") {
+			t.Errorf("expected the match with b to be removed")
+		}
+		if likers := likerIDs(t, "b"); len(likers) != 0 {
+			t.Errorf("expected no likers of b, got %v", likers)
+		}
+		if _, err := store.RewindDecision(ctx, id("a"), time.Minute); !errors.Is(err, storage.ErrNothingToRewind) {
+			t.Errorf("expected ErrNothingToRewind once every decision is undone, got %v", err)
+		}
+
+		// Rewinding a later decision restores the earlier one with
+		// its original time, and its match.
+		put(t, id("e"), id("a"), true)
+		put(t, id("a"), id("e"), true)
+		liked := decided(t, "a", "e")
+		put(t, id("a"), id("e"), false)
+		r := rewind(t, "a")
+		if r.RecipientID != id("e") || r.Liked || r.Restored == nil || !r.Restored.Liked {
+			t.Errorf("expected the pass of e to be undone and the like restored, got %+v", r)
+		}
+		if d := decided(t, "a", "e"); d == nil || *d != *liked {
+			t.Errorf("expected decision %+v after rewinding, got %+v", liked, d)
+		}
+		if !matched(t, "a", "e") || !matched(t, "e", "a") {
+			t.Errorf("expected the match with e to be restored")
+		}
+		if likers := likerIDs(t, "e"); fmt.Sprint(likers) != fmt.Sprint([]string{id("a")}) {
+			t.Errorf("expected a to like e again, got %v", likers)
+		}
+		events, err := store.GetDecisionHistory(ctx, id("a"), id("e"))
+		if err != nil {
+			t.Fatalf("GetDecisionHistory returned error: %v", err)
+		}
+		if len(events) != 1 || !events[0].Liked {
+			t.Errorf("expected the rewound pass to leave the history, got %v", events)
+		}
+		changes, err := store.ListLikeChanges(ctx, id("e"), 0, 10)
+		if err != nil {
+			t.Fatalf("ListLikeChanges returned error: %v", err)
+		}
+		var got []bool
+		for _, c := range changes {
+			got = append(got, c.Liked)
+		}
+		if want := []bool{true, false, true}; fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected like changes %v for e, got %v", want, got)
+		}
+		for _, u := range []string{"a", "b", "c", "e"} {
+			checkLikeCounters(t, store, id(u))
+		}
+
+		// Decisions older than the window cannot be rewound.
+		put(t, id("f"), id("g"), true)
+		time.Sleep(10 * time.Millisecond)
+		if _, err := store.RewindDecision(ctx, id("f"), time.Millisecond); !errors.Is(err, storage.ErrNothingToRewind) {
+			t.Errorf("expected ErrNothingToRewind outside the window, got %v", err)
+		}
+		if d := decided(t, "f", "g"); d == nil || !d.Liked {
+			t.Errorf("expected the decision outside the window to be kept, got %+v", d)
+		}
+	})
+
+	t.Run("SuperLike", func(t *testing.T) {
+		id := userIDs(t)
+		if _, err := store.PutDecision(ctx, id("a"), id("r"), storage.SuperLike); err != nil {
+			t.Fatalf("PutDecision returned error: %v", err)
+		}
+		put(t, id("b"), id("r"), true)
+		likers, _, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 10, storage.NewestFirst)
+		if err != nil {
+			t.Fatalf("ListLikedYou returned error: %v", err)
+		}
+		superLikes := make(map[string]bool)
+		for _, l := range likers {
+			superLikes[l.ActorID] = l.SuperLike
+		}
+		if want := map[string]bool{id("a"): true, id("b"): false}; fmt.Sprint(superLikes) != fmt.Sprint(want) {
+			t.Errorf("expected super like flags %v, got %v", want, superLikes)
+		}
+		newLikers, _, err := store.ListNewLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 10, storage.OldestFirst)
+		if err != nil {
+			t.Fatalf("ListNewLikedYou returned error: %v", err)
+		}
+		if len(newLikers) != 2 || !newLikers[0].SuperLike || newLikers[1].SuperLike {
+			t.Errorf("expected the first new liker to be flagged, got %v", newLikers)
+		}
+		if d, ok, err := store.GetDecision(ctx, id("a"), id("r")); err != nil || !ok || !d.Liked || !d.SuperLike {
+			t.Errorf("expected a stored super like, got %+v, %v, %v", d, ok, err)
+		}
+		decisions, _, err := store.ListMyDecisions(ctx, id("a"), storage.LikedDecisions, nil, 10)
+		if err != nil {
+			t.Fatalf("ListMyDecisions returned error: %v", err)
+		}
+		if len(decisions) != 1 || !decisions[0].SuperLike {
+			t.Errorf("expected the super like among liked decisions, got %v", decisions)
+		}
+		// A plain like replaces the super like.
+		put(t, id("a"), id("r"), true)
+		if d, _, err := store.GetDecision(ctx, id("a"), id("r")); err != nil || !d.Liked || d.SuperLike {
+			t.Errorf("expected a plain like, got %+v, %v", d, err)
+		}
+		events, err := store.GetDecisionHistory(ctx, id("a"), id("r"))
+		if err != nil {
+			t.Fatalf("GetDecisionHistory returned error: %v", err)
+		}
+		if len(events) != 2 || !events[0].SuperLike || events[1].SuperLike {
+			t.Errorf("expected a super like then a like in the history, got %v", events)
+		}
+		checkLikeCounters(t, store, id("r"))
+	})
+
+	t.Run("SuperLikeQuota", func(t *testing.T) {
+		id := userIDs(t)
+		superLike := func(recipient string) error {
+			_, err := store.PutDecision(ctx, id("a"), id(recipient), storage.SuperLike)
+			return err
+		}
+		for i := 0; i < storage.DefaultSuperLikeQuota; i++ {
+			if err := superLike(fmt.Sprint(i)); err != nil {
+				t.Fatalf("super like %d returned error: %v", i, err)
+			}
+		}
+		// Super liking a recipient again does not use up the quota.
+		if err := superLike("0"); err != nil {
+			t.Errorf("expected a repeated super like to be allowed, got %v", err)
+		}
+		if err := superLike("x"); !errors.Is(err, storage.ErrSuperLikeQuotaExceeded) {
+			t.Errorf("expected ErrSuperLikeQuotaExceeded, got %v", err)
+		}
+		if _, ok, err := store.GetDecision(ctx, id("a"), id("x")); err != nil || ok {
+			t.Errorf("expected the rejected super like not to be stored, got %v, %v", ok, err)
+		}
+		put(t, id("a"), id("x"), true)
+		results, err := store.PutDecisions(ctx, id("a"), []storage.Decision{
+			{RecipientID: id("y"), Type: storage.Pass},
+			{RecipientID: id("x"), Type: storage.SuperLike},
+		})
+		if err != nil {
+			t.Fatalf("PutDecisions returned error: %v", err)
+		}
+		if results[0].Err != nil || !errors.Is(results[1].Err, storage.ErrSuperLikeQuotaExceeded) {
+			t.Errorf("expected only the super like to be rejected, got %v", results)
+		}
+		if d, _, err := store.GetDecision(ctx, id("a"), id("x")); err != nil || d.SuperLike {
+			t.Errorf("expected the like of x to be kept, got %+v, %v", d, err)
+		}
+		// Rewinding a super like gives it back.
+		for _, recipient := range []string{"y", "x", "0"} {
+			if r, err := store.RewindDecision(ctx, id("a"), time.Minute); err != nil || r.RecipientID != id(recipient) {
+				t.Fatalf("expected the decision on %s to be rewound, got %+v, %v", recipient, r, err)
+			}
+		}
+		if err := superLike("x"); !errors.Is(err, storage.ErrSuperLikeQuotaExceeded) {
+			t.Errorf("expected the earlier super like of 0 to still count, got %v", err)
+		}
+		if _, err := store.RewindDecision(ctx, id("a"), time.Minute); err != nil {
+			t.Fatalf("RewindDecision returned error: %v", err)
+		}
+		if err := superLike("x"); err != nil {
+			t.Errorf("expected a rewound super like to free the quota, got %v", err)
+		}
+		for _, u := range []string{"a", "x", "0"} {
+			checkLikeCounters(t, store, id(u))
+		}
+	})
+
+	t.Run("FilterUndecided", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("me"), id("liked"), true)
+		put(t, id("me"), id("passed"), false)
+		// Decisions by others on me or on the candidates do not count.
+		put(t, id("c1"), id("me"), true)
+		put(t, id("other"), id("c2"), false)
+		candidates := []string{id("c1"), id("liked"), id("c2"), id("passed"), id("c1"), id("c3")}
+		got, err := store.FilterUndecided(ctx, id("me"), candidates)
+		if err != nil {
+			t.Fatalf("FilterUndecided returned error: %v", err)
+		}
+		if want := []string{id("c1"), id("c2"), id("c3")}; fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected undecided %v, got %v", want, got)
+		}
+		if got, err := store.FilterUndecided(ctx, id("me"), nil); err != nil || len(got) != 0 {
+			t.Errorf("expected nothing for no candidates, got %v and %v", got, err)
+		}
+	})
+
+	t.Run("Blocks", func(t *testing.T) {
+		id := userIDs(t)
+		block := func(t *testing.T, blocker, blocked string) {
+			t.Helper()
+			if err := store.BlockUser(ctx, blocker, blocked); err != nil {
+				t.Fatalf("BlockUser(%s, %s) returned error: %v", blocker, blocked, err)
+			}
+		}
+		unblock := func(t *testing.T, blocker, blocked string) {
+			t.Helper()
+			if err := store.UnblockUser(ctx, blocker, blocked); err != nil {
+				t.Fatalf("UnblockUser(%s, %s) returned error: %v", blocker, blocked, err)
+			}
+		}
+		likerIDs := func(t *testing.T, recipient string) []string {
+			t.Helper()
+			likers, _, err := store.ListLikedYou(ctx, recipient, storage.TimeRange{}, nil, 10, storage.NewestFirst)
+			if err != nil {
+				t.Fatalf("ListLikedYou returned error: %v", err)
+			}
+			return actorIDs(likers)
+		}
+		matched := func(t *testing.T, a, b string) bool {
+			t.Helper()
+			matches, _, err := store.ListMatches(ctx, a, nil, 10)
+			if err != nil {
+				t.Fatalf("ListMatches returned error: %v", err)
+			}
+			return slices.ContainsFunc(matches, func(m storage.Match) bool { return m.UserID == b })
+		}
+		start, err := store.LatestLikeChange(ctx, id("me"))
+		if err != nil {
+			t.Fatalf("LatestLikeChange returned error: %v", err)
+		}
+		put(t, id("x"), id("me"), true)
+		put(t, id("y"), id("me"), true)
+		put(t, id("me"), id("y"), true)
+		put(t, id("z"), id("me"), true)
+
+		// Blocking hides likes in both directions and removes the match.
+		block(t, id("me"), id("y"))
+		block(t, id("z"), id("me"))
+		block(t, id("z"), id("me"))
+		if want, got := []string{id("x")}, likerIDs(t, id("me")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected likers %v while blocked, got %v", want, got)
+		}
+		if got := likerIDs(t, id("y")); len(got) != 0 {
+			t.Errorf("expected no likers for the blocked user, got %v", got)
+		}
+		if matched(t, id("me"), id("y")) || matched(t, id("y"), id("me")) {
+			t.Error("expected the match to be removed by the block")
+		}
+		if n, err := store.CountNewLikedYou(ctx, id("me"), storage.TimeRange{}); err != nil || n != 1 {
+			t.Errorf("expected 1 new like while blocked, got %d, %v", n, err)
+		}
+		for _, u := range []string{"me", "x", "y", "z"} {
+			checkLikeCounters(t, store, id(u))
+		}
+
+		// A like between blocked users is stored but cannot match.
+		put(t, id("me"), id("z"), true)
+		if mutual := put(t, id("z"), id("me"), true); mutual {
+			t.Error("expected no mutual like between blocked users")
+		}
+		if matched(t, id("me"), id("z")) {
+			t.Error("expected no match between blocked users")
+		}
+		got, err := store.FilterUndecided(ctx, id("me"), []string{id("x"), id("y"), id("z"), id("w")})
+		if err != nil {
+			t.Fatalf("FilterUndecided returned error: %v", err)
+		}
+		if want := []string{id("x"), id("w")}; fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected undecided %v, got %v", want, got)
+		}
+		changes, err := store.ListLikeChanges(ctx, id("me"), start, 10)
+		if err != nil {
+			t.Fatalf("ListLikeChanges returned error: %v", err)
+		}
+		if len(changes) != 1 || changes[0].ActorID != id("x") {
+			t.Errorf("expected only the change by x while blocked, got %v", changes)
+		}
+
+		blocked, next, err := store.ListBlocked(ctx, id("me"), nil, 10)
+		if err != nil || next != nil || len(blocked) != 1 || blocked[0].UserID != id("y") || blocked[0].Unix == 0 {
+			t.Errorf("expected to have blocked y, got %v, %v, %v", blocked, next, err)
+		}
+		if blocked, _, err := store.ListBlocked(ctx, id("y"), nil, 10); err != nil || len(blocked) != 0 {
+			t.Errorf("expected the blocked user to have blocked nobody, got %v, %v", blocked, err)
+		}
+
+		// Lifting a block restores the likes and the match unless the
+		// other user still blocks.
+		unblock(t, id("me"), id("y"))
+		unblock(t, id("me"), id("y"))
+		unblock(t, id("me"), id("z"))
+		if !matched(t, id("me"), id("y")) || !matched(t, id("y"), id("me")) {
+			t.Error("expected the match to be restored by the unblock")
+		}
+		if matched(t, id("me"), id("z")) {
+			t.Error("expected no match while z still blocks")
+		}
+		if want, got := []string{id("y"), id("x")}, likerIDs(t, id("me")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected likers %v after unblocking, got %v", want, got)
+		}
+		unblock(t, id("z"), id("me"))
+		if !matched(t, id("me"), id("z")) {
+			t.Error("expected a match once neither user blocks")
+		}
+		for _, u := range []string{"me", "x", "y", "z"} {
+			checkLikeCounters(t, store, id(u))
+		}
+
+		for i := 0; i < 3; i++ {
+			block(t, id("pager"), id(fmt.Sprintf("b%d", i)))
+		}
+		var ids []string
+		for after := (*storage.Cursor)(nil); ; {
+			page, next, err := store.ListBlocked(ctx, id("pager"), after, 2)
+			if err != nil {
+				t.Fatalf("ListBlocked returned error: %v", err)
+			}
+			for _, b := range page {
+				ids = append(ids, b.UserID)
+			}
+			if next == nil {
+				break
+			}
+			after = next
+		}
+		if want := []string{id("b2"), id("b1"), id("b0")}; fmt.Sprint(ids) != fmt.Sprint(want) {
+			t.Errorf("expected blocked users %v, got %v", want, ids)
+		}
+	})
+
+	t.Run("UserVisibility", func(t *testing.T) {
+		id := userIDs(t)
+		setHidden := func(t *testing.T, userID string, hidden bool) {
+			t.Helper()
+			if err := store.SetUserVisibility(ctx, userID, hidden); err != nil {
+				t.Fatalf("SetUserVisibility(%s, %v) returned error: %v", userID, hidden, err)
+			}
+		}
+		likerIDs := func(t *testing.T, recipient string) []string {
+			t.Helper()
+			likers, _, err := store.ListLikedYou(ctx, recipient, storage.TimeRange{}, nil, 10, storage.NewestFirst)
+			if err != nil {
+				t.Fatalf("ListLikedYou returned error: %v", err)
+			}
+			return actorIDs(likers)
+		}
+		users := []string{id("me"), id("h"), id("x"), id("y"), id("o")}
+		start, err := store.LatestLikeChange(ctx, id("me"))
+		if err != nil {
+			t.Fatalf("LatestLikeChange returned error: %v", err)
+		}
+		put(t, id("h"), id("me"), true)
+		put(t, id("x"), id("me"), true)
+		put(t, id("me"), id("h"), true)
+		put(t, id("h"), id("o"), true)
+
+		// Hiding a user hides their likes but keeps their match.
+		setHidden(t, id("h"), true)
+		setHidden(t, id("h"), true)
+		if want, got := []string{id("x")}, likerIDs(t, id("me")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected likers %v while h is hidden, got %v", want, got)
+		}
+		if want, got := []string{id("me")}, likerIDs(t, id("h")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected the hidden user to keep their likers %v, got %v", want, got)
+		}
+		matches, _, err := store.ListMatches(ctx, id("me"), nil, 10)
+		if err != nil || len(matches) != 1 || matches[0].UserID != id("h") {
+			t.Errorf("expected the match with the hidden user to remain, got %v, %v", matches, err)
+		}
+		changes, err := store.ListLikeChanges(ctx, id("me"), start, 10)
+		if err != nil {
+			t.Fatalf("ListLikeChanges returned error: %v", err)
+		}
+		if len(changes) != 1 || changes[0].ActorID != id("x") {
+			t.Errorf("expected only the change by x while h is hidden, got %v", changes)
+		}
+
+		// Decisions made while hidden are stored but not shown.
+		put(t, id("h"), id("y"), true)
+		put(t, id("o"), id("h"), true)
+		if got := likerIDs(t, id("y")); len(got) != 0 {
+			t.Errorf("expected no likers for y while h is hidden, got %v", got)
+		}
+		if _, ok, err := store.GetDecision(ctx, id("h"), id("y")); err != nil || !ok {
+			t.Errorf("expected the hidden user's decision to be stored, got %v, %v", ok, err)
+		}
+		for _, u := range users {
+			checkLikeCounters(t, store, u)
+		}
+
+		// Showing the user again restores their likes.
+		setHidden(t, id("h"), false)
+		setHidden(t, id("h"), false)
+		if want, got := []string{id("x"), id("h")}, likerIDs(t, id("me")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected likers %v once h is shown, got %v", want, got)
+		}
+		if want, got := []string{id("h")}, likerIDs(t, id("y")); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected likers %v for y once h is shown, got %v", want, got)
+		}
+		for _, u := range users {
+			checkLikeCounters(t, store, u)
+		}
+	})
+
+	t.Run("DeleteUserData", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("gone"), id("a"), true)
+		put(t, id("a"), id("gone"), true)
+		put(t, id("gone"), id("b"), true)
+		put(t, id("gone"), id("b"), false)
+		put(t, id("c"), id("gone"), true)
+		put(t, id("gone"), id("c"), true)
+		put(t, id("d"), id("gone"), false)
+		put(t, id("a"), id("b"), true)
+		if err := store.BlockUser(ctx, id("c"), id("gone")); err != nil {
+			t.Fatalf("BlockUser returned error: %v", err)
+		}
+		if err := store.BlockUser(ctx, id("gone"), id("e")); err != nil {
+			t.Fatalf("BlockUser returned error: %v", err)
+		}
+		erasure, err := store.DeleteUserData(ctx, id("gone"), 2)
+		if err != nil {
+			t.Fatalf("DeleteUserData returned error: %v", err)
+		}
+		erasure.Unix = 0
+		if want := (storage.Erasure{Decisions: 6, DecisionEvents: 7, Matches: 1, Blocks: 2}); erasure != want {
+			t.Errorf("expected erasure %+v, got %+v", want, erasure)
+		}
+		for _, u := range []string{"a", "b", "c", "d", "e", "gone"} {
+			checkLikeCounters(t, store, id(u))
+			for _, other := range []string{"a", "b", "c", "d", "e"} {
+				if _, ok, err := store.GetDecision(ctx, id(u), id(other)); err != nil || (ok && u == "gone") {
+					t.Errorf("expected no decision by the erased user on %s, got %v, %v", other, ok, err)
+				}
+				if _, ok, err := store.GetDecision(ctx, id(other), id("gone")); err != nil || ok {
+					t.Errorf("expected no decision by %s on the erased user, got %v, %v", other, ok, err)
+				}
+			}
+		}
+		if sum, err := store.GetLikeSummary(ctx, id("a")); err != nil || sum != (storage.LikeSummary{}) {
+			t.Errorf("expected an empty summary for a, got %+v, %v", sum, err)
+		}
+		if sum, err := store.GetLikeSummary(ctx, id("b")); err != nil || sum != (storage.LikeSummary{Likes: 1, NewLikes: 1}) {
+			t.Errorf("expected only the like by a for b, got %+v, %v", sum, err)
+		}
+		if events, err := store.GetDecisionHistory(ctx, id("gone"), id("b")); err != nil || len(events) != 0 {
+			t.Errorf("expected no history after erasure, got %v, %v", events, err)
+		}
+		if blocked, _, err := store.ListBlocked(ctx, id("c"), nil, 10); err != nil || len(blocked) != 0 {
+			t.Errorf("expected the block of the erased user to be deleted, got %v, %v", blocked, err)
+		}
+		if changes, err := store.ListLikeChanges(ctx, id("a"), 0, 10); err != nil || len(changes) != 0 {
+			t.Errorf("expected no like changes by the erased user, got %v, %v", changes, err)
+		}
+		// Erasing again finds nothing left.
+		erasure, err = store.DeleteUserData(ctx, id("gone"), 2)
+		if err != nil {
+			t.Fatalf("DeleteUserData returned error: %v", err)
+		}
+		if erasure.Decisions != 0 || erasure.DecisionEvents != 0 || erasure.Matches != 0 || erasure.Blocks != 0 || erasure.Unix == 0 {
+			t.Errorf("expected an empty erasure, got %+v", erasure)
+		}
+	})
+
+	t.Run("ExportUserData", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("me"), id("a"), true)
+		put(t, id("me"), id("b"), true)
+		put(t, id("me"), id("b"), false)
+		put(t, id("me"), id("c"), true)
+		put(t, id("a"), id("me"), true)
+		if err := store.BlockUser(ctx, id("me"), id("d")); err != nil {
+			t.Fatalf("BlockUser returned error: %v", err)
+		}
+		var summaries int
+		var decisions, history, matches, blocked []string
+		err := store.ExportUserData(ctx, id("me"), 2, func(batch storage.UserDataExport) error {
+			if n := len(batch.Decisions) + len(batch.History) + len(batch.Matches) + len(batch.Blocked); n > 2 {
+				t.Errorf("expected at most 2 entries per batch, got %d", n)
+			}
+			if batch.Summary != nil {
+				summaries++
+				if want := (storage.LikeSummary{Likes: 1, Matches: 1}); *batch.Summary != want {
+					t.Errorf("expected summary %+v, got %+v", want, *batch.Summary)
+				}
+			}
+			for _, d := range batch.Decisions {
+				decisions = append(decisions, fmt.Sprintf("%s:%v", d.RecipientID, d.Liked))
+			}
+			for _, e := range batch.History {
+				history = append(history, fmt.Sprintf("%s:%v", e.RecipientID, e.Liked))
+			}
+			for _, m := range batch.Matches {
+				matches = append(matches, m.UserID)
+			}
+			for _, b := range batch.Blocked {
+				blocked = append(blocked, b.UserID)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ExportUserData returned error: %v", err)
+		}
+		if summaries != 1 {
+			t.Errorf("expected one summary, got %d", summaries)
+		}
+		if want := []string{id("c") + ":true", id("b") + ":false", id("a") + ":true"}; fmt.Sprint(decisions) != fmt.Sprint(want) {
+			t.Errorf("expected decisions %v, got %v", want, decisions)
+		}
+		if want := []string{id("a") + ":true", id("b") + ":true", id("b") + ":false", id("c") + ":true"}; fmt.Sprint(history) != fmt.Sprint(want) {
+			t.Errorf("expected history %v, got %v", want, history)
+		}
+		if want := []string{id("a")}; fmt.Sprint(matches) != fmt.Sprint(want) {
+			t.Errorf("expected matches %v, got %v", want, matches)
+		}
+		if want := []string{id("d")}; fmt.Sprint(blocked) != fmt.Sprint(want) {
+			t.Errorf("expected blocked users %v, got %v", want, blocked)
+		}
+		stop := errors.New("stop")
+		if err := store.ExportUserData(ctx, id("me"), 2, func(storage.UserDataExport) error { return stop }); !errors.Is(err, stop) {
+			t.Errorf("expected the yield error, got %v", err)
+		}
+	})
+
+	t.Run("LikeChanges", func(t *testing.T) {
+		id := userIDs(t)
+		start, err := store.LatestLikeChange(ctx, id("r"))
+		if err != nil {
+			t.Fatalf("LatestLikeChange returned error: %v", err)
+		}
+		put(t, id("a"), id("r"), true)
+		put(t, id("b"), id("r"), false) // not a like: no change
+		put(t, id("a"), id("r"), true)
+		put(t, id("r"), id("a"), true) // another recipient
+		put(t, id("c"), id("r"), true)
+		put(t, id("a"), id("r"), false)
+		put(t, id("a"), id("r"), false) // already withdrawn: no change
+		changes, err := store.ListLikeChanges(ctx, id("r"), start, 10)
+		if err != nil {
+			t.Fatalf("ListLikeChanges returned error: %v", err)
+		}
+		format := func(changes []storage.LikeChange) []string {
+			var got []string
+			for _, c := range changes {
+				got = append(got, fmt.Sprintf("%s:%v", c.ActorID, c.Liked))
+			}
+			return got
+		}
+		want := []string{id("a") + ":true", id("a") + ":true", id("c") + ":true", id("a") + ":false"}
+		if got := format(changes); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("expected changes %v, got %v", want, got)
+		}
+		for i, c := range changes {
+			if c.Unix == 0 || (i > 0 && c.Seq <= changes[i-1].Seq) {
+				t.Errorf("expected increasing sequence numbers and timestamps, got %v", changes)
+			}
+		}
+		// Resume after the second change, one change at a time.
+		after := changes[1].Seq
+		var resumed []storage.LikeChange
+		for {
+			page, err := store.ListLikeChanges(ctx, id("r"), after, 1)
+			if err != nil {
+				t.Fatalf("ListLikeChanges returned error: %v", err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			resumed = append(resumed, page...)
+			after = page[0].Seq
+		}
+		if got := format(resumed); fmt.Sprint(got) != fmt.Sprint(want[2:]) {
+			t.Errorf("expected resumed changes %v, got %v", want[2:], got)
+		}
+		latest, err := store.LatestLikeChange(ctx, id("r"))
+		if err != nil {
+			t.Fatalf("LatestLikeChange returned error: %v", err)
+		}
+		if changes, err := store.ListLikeChanges(ctx, id("r"), latest, 10); err != nil || len(changes) != 0 {
+			t.Errorf("expected no changes after the latest, got %v, %v", changes, err)
+		}
+	})
+
+	t.Run("SubscribeLikedYou", func(t *testing.T) {
+		id := userIDs(t)
+		changed, unsubscribe := store.SubscribeLikedYou(id("r"))
+		defer unsubscribe()
+		put(t, id("a"), id("r"), true)
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected a notification after a like")
+		}
+		unsubscribe()
+		put(t, id("a"), id("r"), false)
+	})
+
+	t.Run("ConcurrentWrites", func(t *testing.T) {
+		id := userIDs(t)
+		var wg sync.WaitGroup
+		errs := make(chan error, 20)
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := store.PutDecision(ctx, id(fmt.Sprintf("a%d", i)), id("r"), storage.Like)
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("PutDecision returned error: %v", err)
+			}
+		}
+		count, err := store.CountLikedYou(ctx, id("r"), storage.TimeRange{})
+		if err != nil {
+			t.Fatalf("CountLikedYou returned error: %v", err)
+		}
+		if count != 20 {
+			t.Errorf("expected count 20, got %d", count)
+		}
+	})
+
+	t.Run("PutDecisionOnce", func(t *testing.T) {
+		id := userIDs(t)
+		once := func(t *testing.T, key string, ttl time.Duration, actor, recipient string, liked bool) (bool, error) {
+			t.Helper()
+			return store.PutDecisionOnce(ctx, key, ttl, id(actor), id(recipient), likeOrPass(liked))
+		}
+		history := func(t *testing.T, actor, recipient string) int {
+			t.Helper()
+			events, err := store.GetDecisionHistory(ctx, id(actor), id(recipient))
+			if err != nil {
+				t.Fatalf("GetDecisionHistory returned error: %v", err)
+			}
+			return len(events)
+		}
+		put(t, id("b"), id("a"), true)
+		if mutual, err := once(t, "k1", time.Hour, "a", "b", true); err != nil || !mutual {
+			t.Fatalf("expected a mutual like, got %v and %v", mutual, err)
+		}
+		// A retry returns the original result even though b has
+		// passed since, and records nothing.
+		put(t, id("b"), id("a"), false)
+		if mutual, err := once(t, "k1", time.Hour, "a", "b", true); err != nil || !mutual {
+			t.Errorf("expected the retry to report the original mutual like, got %v and %v", mutual, err)
+		}
+		if n := history(t, "a", "b"); n != 1 {
+			t.Errorf("expected the retry not to be recorded, got %d history events", n)
+		}
+		// Keys cannot be reused for another decision but are scoped
+		// to the actor.
+		if _, err := once(t, "k1", time.Hour, "a", "c", true); !errors.Is(err, storage.ErrIdempotencyKeyReused) {
+			t.Errorf("expected ErrIdempotencyKeyReused for another recipient, got %v", err)
+		}
+		if _, err := once(t, "k1", time.Hour, "a", "b", false); !errors.Is(err, storage.ErrIdempotencyKeyReused) {
+			t.Errorf("expected ErrIdempotencyKeyReused for a pass, got %v", err)
+		}
+		if n := history(t, "a", "c"); n != 0 {
+			t.Errorf("expected a rejected key to record nothing, got %d history events", n)
+		}
+		if _, err := once(t, "k1", time.Hour, "c", "b", true); err != nil {
+			t.Errorf("expected another actor to use the same key, got %v", err)
+		}
+		// Once a key expires the call counts as a new decision.
+		if _, err := once(t, "k2", 50*time.Millisecond, "a", "d", true); err != nil {
+			t.Fatalf("PutDecisionOnce returned error: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		if _, err := once(t, "k2", 50*time.Millisecond, "a", "e", true); err != nil {
+			t.Errorf("expected an expired key to be reusable, got %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		collector, ok := store.(interface {
+			DeleteExpiredIdempotencyKeys(context.Context, int) (int64, error)
+		})
+		if !ok {
+			t.Fatalf("%T cannot delete expired idempotency keys", store)
+		}
+		if n, err := collector.DeleteExpiredIdempotencyKeys(ctx, 1); err != nil || n < 1 {
+			t.Errorf("expected expired keys to be deleted, got %d and %v", n, err)
+		}
+		if _, err := once(t, "k1", time.Hour, "a", "b", true); err != nil {
+			t.Errorf("expected live keys to survive garbage collection, got %v", err)
+		}
+		if n := history(t, "a", "b"); n != 1 {
+			t.Errorf("expected the retry not to be recorded after garbage collection, got %d history events", n)
+		}
+		// A super like is a different decision from a like.
+		if _, err := store.PutDecisionOnce(ctx, "k1", time.Hour, id("a"), id("b"), storage.SuperLike); !errors.Is(err, storage.ErrIdempotencyKeyReused) {
+			t.Errorf("expected ErrIdempotencyKeyReused for a super like, got %v", err)
+		}
+	})
+
+	t.Run("PutDecisions", func(t *testing.T) {
+		id := userIDs(t)
+		put(t, id("b"), id("a"), true)
+		put(t, id("c"), id("a"), true)
+		results, err := store.PutDecisions(ctx, id("a"), []storage.Decision{
+			{RecipientID: id("b"), Type: storage.Like},
+			{RecipientID: id("c"), Type: storage.Like},
+			{RecipientID: id("d"), Type: storage.Like},
+			// A later decision on the same recipient overwrites
+			// the earlier one and removes its match.
+			{RecipientID: id("c"), Type: storage.Pass},
+		})
+		if err != nil {
+			t.Fatalf("PutDecisions returned error: %v", err)
+		}
+		var mutual []bool
+		for _, r := range results {
+			if r.Err != nil {
+				t.Errorf("expected every decision to be stored, got %v", r.Err)
+			}
+			mutual = append(mutual, r.Mutual)
+		}
+		if want := []bool{true, true, false, false}; fmt.Sprint(mutual) != fmt.Sprint(want) {
+			t.Errorf("expected mutual flags %v, got %v", want, mutual)
+		}
+		for recipient, want := range map[string][]string{"c": nil, "d": {id("a")}} {
+			likers, _, err := store.ListLikedYou(ctx, id(recipient), storage.TimeRange{}, nil, 10, storage.NewestFirst)
+			if err != nil {
+				t.Fatalf("ListLikedYou returned error: %v", err)
+			}
+			if got := actorIDs(likers); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected likers %v for %s, got %v", want, recipient, got)
+			}
+		}
+		matches, _, err := store.ListMatches(ctx, id("a"), nil, 10)
+		if err != nil {
+			t.Fatalf("ListMatches returned error: %v", err)
+		}
+		if len(matches) != 1 || matches[0].UserID != id("b") {
+			t.Errorf("expected a single match with b, got %v", matches)
+		}
+		events, err := store.GetDecisionHistory(ctx, id("a"), id("c"))
+		if err != nil {
+			t.Fatalf("GetDecisionHistory returned error: %v", err)
+		}
+		if len(events) != 2 || !events[0].Liked || events[1].Liked {
+			t.Errorf("expected a like then a pass in the history, got %v", events)
+		}
+		for _, u := range []string{"a", "b", "c", "d"} {
+			checkLikeCounters(t, store, id(u))
+		}
+		if results, err := store.PutDecisions(ctx, id("a"), nil); err != nil || len(results) != 0 {
+			t.Errorf("expected no results for an empty batch, got %v and %v", results, err)
+		}
+	})
+
+	t.Run("ConcurrentBatches", func(t *testing.T) {
+		// Users like each other in batches listing the others in
+		// opposite orders; the store must not deadlock.
+		id := userIDs(t)
+		users := make([]string, 8)
+		for i := range users {
+			users[i] = id(fmt.Sprintf("u%d", i))
+		}
+		var wg sync.WaitGroup
+		errs := make(chan error, len(users))
+		for i, actor := range users {
+			var batch []storage.Decision
+			for _, r := range users {
+				if r != actor {
+					batch = append(batch, storage.Decision{RecipientID: r, Type: storage.Like})
+				}
+			}
+			if i%2 == 1 {
+				slices.Reverse(batch)
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results, err := store.PutDecisions(ctx, actor, batch)
+				for _, r := range results {
+					err = errors.Join(err, r.Err)
+				}
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("PutDecisions returned error: %v", err)
+			}
+		}
+		for _, u := range users {
+			sum, err := store.GetLikeSummary(ctx, u)
+			if err != nil {
+				t.Fatalf("GetLikeSummary returned error: %v", err)
+			}
+			n := uint64(len(users) - 1)
+			if want := (storage.LikeSummary{Likes: n, Matches: n}); sum != want {
+				t.Errorf("expected summary %+v for %s, got %+v", want, u, sum)
+			}
+		}
+	})
+
+	t.Run("NonPositiveLimit", func(t *testing.T) {
+		id := userIDs(t)
+		if _, _, err := store.ListLikedYou(ctx, id("r"), storage.TimeRange{}, nil, 0, storage.NewestFirst); err == nil {
+			t.Errorf("expected ListLikedYou to reject a zero limit")
+		}
+		if _, _, err := store.ListNewLikedYou(ctx, id("r"), storage.TimeRange{}, nil, -1, storage.NewestFirst); err == nil {
+			t.Errorf("expected ListNewLikedYou to reject a negative limit")
+		}
+	})
+}
+
+// passTTL is the pass TTL of the pass expiry tests.  A fresh pass
+// outlives a few queries, and an old one does not take long to wait
+// for.
+const passTTL = 500 * time.Millisecond
+
+// passExpiringStore is a DecisionStore whose expired passes can be
+// swept.
+type passExpiringStore interface {
+	storage.DecisionStore
+	sweeper.Source
+}
+
+// passTTLBackends lists the backends of the pass expiry tests, each
+// configured with the given pass TTL.
+var passTTLBackends = []struct {
+	name     string
+	newStore func(t *testing.T, ttl time.Duration) passExpiringStore
+}{
+	{"memory", func(_ *testing.T, ttl time.Duration) passExpiringStore {
+		return storage.NewMemoryStore(storage.WithMemoryPassTTL(ttl))
+	}},
+	{"postgres", func(t *testing.T, ttl time.Duration) passExpiringStore {
+		return newPostgresStoreWith(t, storage.WithPassTTL(ttl))
+	}},
+}
+
+// TestPassTTLConformance checks that both backends expire passes the
+// same way.
+func TestPassTTLConformance(t *testing.T) {
+	for _, b := range passTTLBackends {
+		t.Run(b.name, func(t *testing.T) {
+			runPassTTLConformance(t, b.newStore(t, passTTL))
+		})
+	}
+}
+
+func runPassTTLConformance(t *testing.T, store passExpiringStore) {
+	ctx := context.Background()
+	id := userIDs(t)
+	put := func(actor, recipient string, decision storage.DecisionType) {
+		t.Helper()
+		if _, err := store.PutDecision(ctx, id(actor), id(recipient), decision); err != nil {
+			t.Fatalf("PutDecision(%s, %s, %v) returned error: %v", actor, recipient, decision, err)
+		}
+	}
+	candidates := []string{id("p1"), id("liked"), id("p2"), id("p3"), id("fresh"), id("new")}
+	filter := func() []string {
+		t.Helper()
+		ids, err := store.FilterUndecided(ctx, id("a"), candidates)
+		if err != nil {
+			t.Fatalf("FilterUndecided returned error: %v", err)
+		}
+		return ids
+	}
+
+	for _, r := range []string{"p1", "p2", "p3"} {
+		put("a", r, storage.Pass)
+	}
+	put("a", "liked", storage.Like)
+	put("liked", "a", storage.Like)
+	put("x", "y", storage.Pass)
+	if want, got := []string{id("fresh"), id("new")}, filter(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected undecided %v before the passes expired, got %v", want, got)
+	}
+
+	// An expired pass counts as undecided; an old like never does.
+	time.Sleep(passTTL + 100*time.Millisecond)
+	put("a", "fresh", storage.Pass)
+	undecided := []string{id("p1"), id("p2"), id("p3"), id("new")}
+	if got := filter(); fmt.Sprint(got) != fmt.Sprint(undecided) {
+		t.Errorf("expected undecided %v once the passes expired, got %v", undecided, got)
+	}
+
+	// With two passes per batch the first batch is full and the second
+	// is short, which ends the sweep.  Other suites sharing a database
+	// via TEST_PG_DSN may leave expired passes behind, so only a lower
+	// bound is checked.
+	n, err := store.DeleteExpiredPasses(ctx, 2)
+	if err != nil || n < 3 {
+		t.Fatalf("expected the sweep to delete the 3 expired passes, got %d, %v", n, err)
+	}
+	for _, r := range []string{"p1", "p2", "p3"} {
+		if _, ok, err := store.GetDecision(ctx, id("a"), id(r)); err != nil || ok {
+			t.Errorf("expected the expired pass on %s to be deleted, got %v, %v", r, ok, err)
+		}
+	}
+	for _, r := range []string{"liked", "fresh"} {
+		if _, ok, err := store.GetDecision(ctx, id("a"), id(r)); err != nil || !ok {
+			t.Errorf("expected the decision on %s to be kept, got %v, %v", r, ok, err)
+		}
+	}
+	if got := filter(); fmt.Sprint(got) != fmt.Sprint(undecided) {
+		t.Errorf("expected undecided %v after the sweep, got %v", undecided, got)
+	}
+	history, err := store.GetDecisionHistory(ctx, id("a"), id("p1"))
+	if err != nil || len(history) != 1 || history[0].Liked {
+		t.Errorf("expected the swept pass to stay in the history, got %v, %v", history, err)
+	}
+	matches, _, err := store.ListMatches(ctx, id("a"), nil, 10)
+	if err != nil || len(matches) != 1 {
+		t.Errorf("expected the match with liked to be kept, got %v, %v", matches, err)
+	}
+	for _, u := range []string{"a", "liked", "p1"} {
+		checkLikeCounters(t, store, id(u))
+	}
+	if n, err := store.DeleteExpiredPasses(ctx, 2); err != nil || n != 0 {
+		t.Errorf("expected nothing left to sweep, got %d, %v", n, err)
+	}
+
+	// A rewind neither undoes a swept pass nor restores one.
+	if _, err := store.RewindDecision(ctx, id("x"), time.Hour); !errors.Is(err, storage.ErrNothingToRewind) {
+		t.Errorf("expected ErrNothingToRewind for a swept pass, got %v", err)
+	}
+	put("a", "p1", storage.Like)
+	r, err := store.RewindDecision(ctx, id("a"), time.Hour)
+	if err != nil || r.RecipientID != id("p1") || !r.Liked || r.Restored != nil {
+		t.Errorf("expected the like of p1 to be undone with nothing restored, got %+v, %v", r, err)
+	}
+	if _, ok, err := store.GetDecision(ctx, id("a"), id("p1")); err != nil || ok {
+		t.Errorf("expected no decision on p1 after the rewind, got %v, %v", ok, err)
+	}
+	if got := filter(); fmt.Sprint(got) != fmt.Sprint(undecided) {
+		t.Errorf("expected undecided %v after the rewind, got %v", undecided, got)
+	}
+	for _, u := range []string{"a", "p1"} {
+		checkLikeCounters(t, store, id(u))
+	}
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"explore_service/internal/storage"
+	"explore_service/internal/sweeper"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestPassSweeper checks that a sweep deletes the expired passes of
+// every backend and records what it did.
+func TestPassSweeper(t *testing.T) {
+	for _, b := range passTTLBackends {
+		t.Run(b.name, func(t *testing.T) {
+			testPassSweeper(t, b.newStore(t, passTTL))
+		})
+	}
+}
+
+func testPassSweeper(t *testing.T, store passExpiringStore) {
+	ctx := context.Background()
+	id := userIDs(t)
+	put := func(actor, recipient string, decision storage.DecisionType) {
+		t.Helper()
+		if _, err := store.PutDecision(ctx, id(actor), id(recipient), decision); err != nil {
+			t.Fatalf("PutDecision returned error: %v", err)
+		}
+	}
+	put("a", "b", storage.Pass)
+	put("a", "c", storage.Like)
+	put("b", "a", storage.Like)
+	time.Sleep(passTTL + 100*time.Millisecond)
+	put("a", "e", storage.Pass)
+
+	reg := prometheus.NewRegistry()
+	sw := sweeper.New(store, time.Hour, 10, reg)
+	// Other suites sharing a database via TEST_PG_DSN may leave expired
+	// passes behind, so the metrics are compared with what the sweep
+	// reports.
+	n, err := sw.Sweep(ctx)
+	if err != nil || n < 1 {
+		t.Fatalf("expected the sweep to delete the expired pass, got %d, %v", n, err)
+	}
+	if _, ok, err := store.GetDecision(ctx, id("a"), id("b")); err != nil || ok {
+		t.Errorf("expected the expired pass to be deleted, got %v, %v", ok, err)
+	}
+	if _, ok, err := store.GetDecision(ctx, id("a"), id("e")); err != nil || !ok {
+		t.Errorf("expected the recent pass to be kept, got %v, %v", ok, err)
+	}
+	for _, u := range []string{"a", "b", "c"} {
+		checkLikeCounters(t, store, id(u))
+	}
+	if n, err := sw.Sweep(ctx); err != nil || n != 0 {
+		t.Errorf("expected nothing left to sweep, got %d, %v", n, err)
+	}
+
+	want := fmt.Sprintf(`
+# HELP explore_pass_sweeper_deleted_passes_total Number of expired passes deleted by the pass sweeper.
+# TYPE explore_pass_sweeper_deleted_passes_total counter
+explore_pass_sweeper_deleted_passes_total %d
+# HELP explore_pass_sweeper_sweeps_total Number of pass sweeps by result (ok or error).
+# TYPE explore_pass_sweeper_sweeps_total counter
+explore_pass_sweeper_sweeps_total{result="ok"} 2
+`, n)
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "explore_pass_sweeper_deleted_passes_total", "explore_pass_sweeper_sweeps_total"); err != nil {
+		t.Error(err)
+	}
+}
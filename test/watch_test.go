@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"explore_service/internal/storage"
+	explorepb "explore_service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestWatchLikedYou streams like changes over gRPC, including more
+// changes than fit in one batch, and resumes a stream from a token.
+func TestWatchLikedYou(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			store := b.newStore(t)
+			client := explorepb.NewExploreServiceClient(startGRPCServer(t, store))
+			id := userIDs(t)
+			put := func(actor string, liked bool) {
+				t.Helper()
+				if _, err := store.PutDecision(context.Background(), id(actor), id("r"), likeOrPass(liked)); err != nil {
+					t.Fatalf("PutDecision returned error: %v", err)
+				}
+			}
+			watch := func(ctx context.Context, token string) explorepb.ExploreService_WatchLikedYouClient {
+				t.Helper()
+				stream, err := client.WatchLikedYou(ctx, &explorepb.WatchLikedYouRequest{RecipientUserId: id("r"), ResumeToken: &token})
+				if err != nil {
+					t.Fatalf("WatchLikedYou returned error: %v", err)
+				}
+				return stream
+			}
+			recv := func(stream explorepb.ExploreService_WatchLikedYouClient, n int) ([]string, []string) {
+				t.Helper()
+				var got, tokens []string
+				for len(got) < n {
+					resp, err := stream.Recv()
+					if err != nil {
+						t.Fatalf("Recv returned error: %v", err)
+					}
+					if l := resp.GetLiker(); l != nil {
+						got = append(got, "+"+l.GetActorId())
+					} else {
+						got = append(got, "-"+resp.GetRemoved().GetActorId())
+					}
+					tokens = append(tokens, resp.GetResumeToken())
+				}
+				return got, tokens
+			}
+
+			latest, err := store.LatestLikeChange(context.Background(), id("r"))
+			if err != nil {
+				t.Fatalf("LatestLikeChange returned error: %v", err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			stream := watch(ctx, storage.EncodeResumeToken(latest))
+			var want []string
+			// The test server sends batches of 10 changes.
+			for i := 0; i < 25; i++ {
+				put(fmt.Sprintf("a%02d", i), true)
+				want = append(want, "+"+id(fmt.Sprintf("a%02d", i)))
+			}
+			put("b", true)
+			put("a00", false)
+			put("c", false) // not a like: no change
+			want = append(want, "+"+id("b"), "-"+id("a00"))
+			got, tokens := recv(stream, len(want))
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected changes %v, got %v", want, got)
+			}
+			cancel()
+			if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+				t.Errorf("expected Canceled after the client disconnected, got %v", err)
+			}
+
+			// Resuming continues after the given change, including
+			// changes made while disconnected.
+			put("d", true)
+			ctx, cancel = context.WithCancel(context.Background())
+			defer cancel()
+			stream = watch(ctx, tokens[24])
+			want = append(want[25:], "+"+id("d"))
+			if got, _ := recv(stream, len(want)); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("expected resumed changes %v, got %v", want, got)
+			}
+			put("e", true)
+			if got, _ := recv(stream, 1); fmt.Sprint(got) != fmt.Sprint([]string{"+" + id("e")}) {
+				t.Errorf("expected a live change after resuming, got %v", got)
+			}
+		})
+	}
+}